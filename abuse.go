@@ -0,0 +1,119 @@
+package domaininfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// whoisAbuseFields are the WHOIS keys that carry an abuse address in the
+// ARIN and RIPE-style formats.
+var whoisAbuseFields = []string{"orgabuseemail", "abuse-mailbox", "abuse-email"}
+
+type rdapNetwork struct {
+	Entities []rdapEntity `json:"entities"`
+	Remarks  []rdapRemark `json:"remarks"`
+}
+
+func AbuseContact(ip string) (string, error) {
+	return defaultClient.AbuseContact(context.Background(), ip)
+}
+
+// AbuseContact returns the abuse email address of the network that holds
+// ip. It reads the abuse entity of the RDAP IP network record, then its
+// remarks, and falls back to the regional registry's WHOIS record. It
+// returns ErrNoAbuseContact when the records name none.
+func (c *Client) AbuseContact(ctx context.Context, ip string) (string, error) {
+	if net.ParseIP(ip) == nil {
+		return "", ErrInvalidIP
+	}
+
+	email, rdapErr := c.rdapAbuseContact(ctx, ip)
+	if email != "" {
+		return email, nil
+	}
+
+	email, whoisErr := c.whoisAbuseContact(ctx, ip)
+	if email != "" {
+		return email, nil
+	}
+
+	if rdapErr != nil && whoisErr != nil {
+		return "", rdapErr
+	}
+	return "", ErrNoAbuseContact
+}
+
+func (c *Client) rdapAbuseContact(ctx context.Context, ip string) (string, error) {
+	body, err := c.fetchRDAP(ctx, fmt.Sprintf("%s/ip/%s", rdapBootstrap, ip))
+	if err != nil {
+		return "", fmt.Errorf("RDAP query failed: %w", err)
+	}
+
+	var network rdapNetwork
+	if err := json.Unmarshal(body, &network); err != nil {
+		return "", err
+	}
+
+	if email := abuseEntityEmail(network.Entities); email != "" {
+		return email, nil
+	}
+	return remarkEmail(network.Remarks), nil
+}
+
+// abuseEntityEmail searches entities, including nested ones, for an entity
+// with the abuse role and returns its vCard email, or an address mentioned
+// in its remarks.
+func abuseEntityEmail(entities []rdapEntity) string {
+	for _, entity := range entities {
+		if containsString(entity.Roles, "abuse") {
+			if email := entity.vcardText("email"); email != "" {
+				return email
+			}
+			if email := remarkEmail(entity.Remarks); email != "" {
+				return email
+			}
+		}
+		if email := abuseEntityEmail(entity.Entities); email != "" {
+			return email
+		}
+	}
+	return ""
+}
+
+// remarkEmail returns the first email address in remarks that mention
+// abuse.
+func remarkEmail(remarks []rdapRemark) string {
+	for _, remark := range remarks {
+		text := remark.Title + "\n" + strings.Join(remark.Description, "\n")
+		if !strings.Contains(strings.ToLower(text), "abuse") {
+			continue
+		}
+		if email := emailRegex.FindString(text); email != "" {
+			return email
+		}
+	}
+	return ""
+}
+
+func (c *Client) whoisAbuseContact(ctx context.Context, ip string) (string, error) {
+	referral, err := c.queryWHOIS(ctx, ianaWHOIS, ip)
+	if err != nil {
+		return "", fmt.Errorf("IANA WHOIS query failed: %w", err)
+	}
+	server := whoisField(referral, "whois", "refer")
+	if server == "" {
+		return "", fmt.Errorf("no WHOIS server known for %s", ip)
+	}
+
+	raw, err := c.queryWHOIS(ctx, server, ip)
+	if err != nil {
+		return "", fmt.Errorf("WHOIS query to %s failed: %w", server, err)
+	}
+	return emailRegex.FindString(whoisField(raw, whoisAbuseFields...)), nil
+}
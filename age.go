@@ -0,0 +1,49 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// creationDate returns when the registrable domain of domain was created,
+// preferring RDAP's structured events and falling back to WHOIS. When
+// neither yields a date the error wraps ErrNoCreationDate, along with any
+// lookup failures.
+func (c *Client) creationDate(ctx context.Context, domain string) (time.Time, error) {
+	apex, err := RegistrableDomain(domain)
+	if err != nil {
+		apex = domain
+	}
+
+	rdap, rdapErr := c.LookupRDAP(ctx, apex)
+	if rdapErr == nil && !rdap.Created.IsZero() {
+		return rdap.Created, nil
+	}
+
+	whois, whoisErr := c.LookupWHOIS(ctx, apex)
+	if whoisErr == nil && !whois.Created.IsZero() {
+		return whois.Created, nil
+	}
+
+	if err := errors.Join(rdapErr, whoisErr); err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrNoCreationDate, err)
+	}
+	return time.Time{}, ErrNoCreationDate
+}
+
+func IsNewlyRegistered(domain string, within time.Duration) (bool, error) {
+	return defaultClient.IsNewlyRegistered(context.Background(), domain, within)
+}
+
+// IsNewlyRegistered reports whether the registrable domain of domain was
+// created less than within ago, according to its RDAP or WHOIS creation
+// date. It returns ErrNoCreationDate when the date cannot be determined.
+func (c *Client) IsNewlyRegistered(ctx context.Context, domain string, within time.Duration) (bool, error) {
+	created, err := c.creationDate(ctx, c.normalizeDomain(domain))
+	if err != nil {
+		return false, err
+	}
+	return time.Since(created) < within, nil
+}
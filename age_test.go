@@ -0,0 +1,78 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeWHOIS answers every WHOIS query with response and returns its
+// address.
+func fakeWHOIS(t *testing.T, response string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.Read(make([]byte, 512))
+				conn.Write([]byte(response))
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestIsNewlyRegistered(t *testing.T) {
+	created := time.Now().Add(-5 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"ldhName":"example.com","events":[{"eventAction":"registration","eventDate":%q}]}`, created)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: &http.Client{Transport: rewriteTransport{server}}}
+	for _, tt := range []struct {
+		within time.Duration
+		want   bool
+	}{
+		{30 * 24 * time.Hour, true},
+		{24 * time.Hour, false},
+	} {
+		got, err := client.IsNewlyRegistered(context.Background(), "www.example.com", tt.within)
+		if err != nil {
+			t.Fatalf("IsNewlyRegistered(%v): %v", tt.within, err)
+		}
+		if got != tt.want {
+			t.Errorf("IsNewlyRegistered(%v) = %v, want %v", tt.within, got, tt.want)
+		}
+	}
+}
+
+func TestIsNewlyRegisteredNoCreationDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ldhName":"example.com"}`)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HTTPClient:   &http.Client{Transport: rewriteTransport{server}},
+		WHOISServers: map[string]string{"com": fakeWHOIS(t, "Domain Name: EXAMPLE.COM\r\n")},
+	}
+	_, err := client.IsNewlyRegistered(context.Background(), "example.com", time.Hour)
+	if !errors.Is(err, ErrNoCreationDate) {
+		t.Errorf("IsNewlyRegistered error = %v, want ErrNoCreationDate", err)
+	}
+}
@@ -0,0 +1,52 @@
+package domaininfo
+
+import (
+	"net"
+	"strings"
+)
+
+// bundledAnycastRanges lists well-known anycast prefixes: public DNS
+// resolvers, DNS root servers and CDN or accelerator edges. Cloudflare's
+// edge ranges are anycast too and are checked separately.
+var bundledAnycastRanges = []string{
+	// Public resolvers.
+	"1.1.1.0/24", "1.0.0.0/24", "2606:4700:4700::/48", // Cloudflare
+	"8.8.8.0/24", "8.8.4.0/24", "2001:4860:4860::/48", // Google
+	"9.9.9.0/24", "149.112.112.0/24", "2620:fe::/48", // Quad9
+	"208.67.222.0/24", "208.67.220.0/24", "2620:119:35::/48", "2620:119:53::/48", // OpenDNS
+	"94.140.14.0/24", "94.140.15.0/24", // AdGuard
+	"76.76.2.0/24", "76.76.10.0/24", // Control D
+
+	// Root servers.
+	"198.41.0.0/24", "170.247.170.0/24", "192.33.4.0/24", "199.7.91.0/24",
+	"192.203.230.0/24", "192.5.5.0/24", "192.112.36.0/24", "198.97.190.0/24",
+	"192.36.148.0/24", "192.58.128.0/24", "193.0.14.0/24", "199.7.83.0/24",
+	"202.12.27.0/24",
+
+	// CDN and accelerator edges.
+	"151.101.0.0/16", "2a04:4e42::/32", // Fastly
+	"76.76.21.0/24",                 // Vercel
+	"75.2.0.0/17", "99.83.128.0/17", // AWS Global Accelerator
+}
+
+var anycastRanges = parseIPRanges(bundledAnycastRanges)
+
+const anycastWarning = "anycast address: coordinates describe one edge location, not where every client is served"
+
+// IsLikelyAnycast reports whether ip falls in a well-known anycast prefix,
+// in which case a single geolocation point is misleading. The bundled list
+// is not exhaustive.
+func IsLikelyAnycast(ip string) (bool, error) {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return false, ErrInvalidIP
+	}
+	return anycastRanges.contains(parsed) || IsCloudflareIP(parsed.String()), nil
+}
+
+func markAnycast(location *LocationDetails, ip string) {
+	if anycast, _ := IsLikelyAnycast(ip); anycast {
+		location.Anycast = true
+		location.Warnings = append(location.Warnings, anycastWarning)
+	}
+}
@@ -0,0 +1,39 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+func ApexResolves(domain string) (bool, error) {
+	return defaultClient.ApexResolves(context.Background(), domain)
+}
+
+// ApexResolves reports whether the registrable domain of domain, the bare
+// apex, has A or AAAA records of its own. It is false when the apex does
+// not exist, has no addresses, or is only an alias for one of its own
+// subdomains such as www, catching sites where www.example.com works but
+// example.com is broken.
+func (c *Client) ApexResolves(ctx context.Context, domain string) (bool, error) {
+	domain = c.normalizeDomain(domain)
+	apex, err := RegistrableDomain(domain)
+	if err != nil {
+		return false, err
+	}
+
+	chain, ips, err := c.ResolveChain(ctx, apex)
+	if errors.Is(err, ErrDomainNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, target := range chain {
+		if strings.HasSuffix(target, "."+apex) {
+			return false, nil
+		}
+	}
+	return len(ips) > 0, nil
+}
@@ -0,0 +1,62 @@
+package domaininfo
+
+import "strings"
+
+// aliasDNSProviders maps nameserver name fragments to DNS hosts that can
+// flatten a CNAME-like record at the zone apex (ALIAS, ANAME or CNAME
+// flattening).
+var aliasDNSProviders = []struct {
+	fragment string
+	name     string
+}{
+	{".ns.cloudflare.com", "Cloudflare"},
+	{".awsdns-", "Route 53"},
+	{".azure-dns.", "Azure DNS"},
+	{".dnsimple.com", "DNSimple"},
+	{".dnsmadeeasy.com", "DNS Made Easy"},
+	{".nsone.net", "NS1"},
+	{".vercel-dns.com", "Vercel"},
+}
+
+func aliasDNSProvider(nameservers []string) string {
+	for _, ns := range nameservers {
+		ns = "." + strings.ToLower(ns)
+		for _, provider := range aliasDNSProviders {
+			if strings.Contains(ns, provider.fragment) {
+				return provider.name
+			}
+		}
+	}
+	return ""
+}
+
+// isApexAlias guesses whether snapshot describes a zone apex served through
+// a flattened alias. An apex cannot hold a CNAME, yet load balancers and
+// CDNs only hand out hostnames; an apex on an alias-capable DNS host whose
+// A records land in a cloud provider's ranges is almost certainly
+// flattened. Cloudflare edge addresses are excluded, since those are
+// ordinary proxied records.
+func isApexAlias(snapshot *DNSSnapshot) bool {
+	domain := strings.ToLower(snapshot.Domain)
+	if snapshot.DNSProvider == "" {
+		return false
+	}
+	if apex, err := RegistrableDomain(domain); err != nil || apex != domain {
+		return false
+	}
+	if snapshot.CNAME != "" && !strings.EqualFold(snapshot.CNAME, domain) {
+		return false
+	}
+
+	var candidates []string
+	for _, ip := range append(append([]string(nil), snapshot.A...), snapshot.AAAA...) {
+		if !IsCloudflareIP(ip) {
+			candidates = append(candidates, ip)
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+	_, inCloud := CloudProvider(&DomainInfo{IPAddress: candidates[0], IPAddresses: candidates})
+	return inCloud
+}
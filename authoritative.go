@@ -0,0 +1,117 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// authoritativeIPs queries the nameservers of the zone containing domain
+// directly, with recursion disabled, and returns the A and AAAA addresses
+// the first responsive one serves.
+func (c *Client) authoritativeIPs(ctx context.Context, domain string) ([]string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	nameservers, err := c.zoneNameservers(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, ns := range nameservers {
+		addrs, err := c.resolver().LookupHost(ctx, ns)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		ips, err := c.queryAuthoritativeServer(ctx, net.JoinHostPort(addrs[0], "53"), domain)
+		if err == nil {
+			return ips, nil
+		}
+		lastErr = fmt.Errorf("%s: %w", ns, err)
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no authoritative nameserver answered")
+	}
+	return nil, lastErr
+}
+
+// queryAuthoritativeServer asks server for the addresses of domain. CNAMEs
+// are followed as far as the answer goes; a chain leaving the zone yields no
+// addresses.
+func (c *Client) queryAuthoritativeServer(ctx context.Context, server, domain string) ([]string, error) {
+	var ips []string
+	for _, qtype := range []uint16{dnsTypeA, dnsTypeAAAA} {
+		resp, err := c.exchange(ctx, server, domain, qtype, false)
+		if err != nil {
+			return nil, err
+		}
+		if resp.RCode != 0 {
+			return nil, fmt.Errorf("answered %s", rcodeName(resp.RCode))
+		}
+
+		name := domain
+		for hops := 0; hops < maxCNAMEChain; hops++ {
+			target := recordData(resp.Answers, name, dnsTypeCNAME)
+			if len(target) == 0 {
+				break
+			}
+			name = target[0]
+		}
+		ips = append(ips, recordData(resp.Answers, name, qtype)...)
+	}
+	return ips, nil
+}
+
+// zoneNameservers finds the NS records of the closest enclosing zone by
+// walking up from domain towards its public suffix.
+func (c *Client) zoneNameservers(ctx context.Context, domain string) ([]string, error) {
+	suffix := PublicSuffix(domain)
+	for name := domain; name != "" && name != suffix; {
+		records, err := c.resolver().LookupNS(ctx, name)
+		if err == nil && len(records) > 0 {
+			nameservers := make([]string, len(records))
+			for i, record := range records {
+				nameservers[i] = strings.TrimSuffix(record.Host, ".")
+			}
+			return nameservers, nil
+		}
+
+		_, parent, found := strings.Cut(name, ".")
+		if !found {
+			break
+		}
+		name = parent
+	}
+	return nil, fmt.Errorf("no nameservers found for %s", domain)
+}
+
+// compareAuthoritative fills info's authoritative fields when
+// QueryAuthoritative is set. Failure to reach the authoritative servers
+// leaves them empty rather than failing validation.
+func (c *Client) compareAuthoritative(ctx context.Context, info *DomainInfo) {
+	if !c.QueryAuthoritative {
+		return
+	}
+
+	ips, err := c.authoritativeIPs(ctx, info.CleanDomain)
+	if err != nil || len(ips) == 0 {
+		return
+	}
+	sort.Strings(ips)
+	info.AuthoritativeIPs = ips
+
+	recursive := make(map[string]bool, len(info.IPAddresses))
+	for _, ip := range info.IPAddresses {
+		recursive[net.ParseIP(ip).String()] = true
+	}
+	authoritative := make(map[string]bool, len(ips))
+	for _, ip := range ips {
+		authoritative[ip] = true
+	}
+	info.AuthoritativeMismatch = !sameSet(recursive, authoritative)
+}
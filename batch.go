@@ -0,0 +1,148 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultConcurrency = 8
+
+// DomainResult is the outcome of validating one input in a batch.
+// CleanDomain and UnicodeDomain are set even when validation fails, so
+// reports can show the domain in both forms.
+type DomainResult struct {
+	Input         string
+	CleanDomain   string
+	UnicodeDomain string
+	Info          *DomainInfo
+	Err           error
+}
+
+func (c *Client) concurrency() int {
+	if c.Concurrency > 0 {
+		return c.Concurrency
+	}
+	return defaultConcurrency
+}
+
+func ValidateDomains(inputs []string) []DomainResult {
+	return defaultClient.ValidateDomains(context.Background(), inputs)
+}
+
+// ValidateDomains validates inputs concurrently, using up to
+// Client.Concurrency workers, and returns the results in input order.
+// Client.Progress is reported as each one finishes.
+func (c *Client) ValidateDomains(ctx context.Context, inputs []string) []DomainResult {
+	results := make([]DomainResult, len(inputs))
+	jobs := make(chan int)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		completed int
+	)
+	for w := 0; w < min(c.concurrency(), len(inputs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.validateOne(ctx, inputs[i])
+				if c.Progress != nil {
+					mu.Lock()
+					completed++
+					c.Progress(completed, len(inputs))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range inputs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func FilterValid(inputs []string) []*DomainInfo {
+	return defaultClient.FilterValid(context.Background(), inputs)
+}
+
+// FilterValid validates inputs like ValidateDomains and returns only the
+// domains that fully validated, in input order, dropping failures.
+func (c *Client) FilterValid(ctx context.Context, inputs []string) []*DomainInfo {
+	var valid []*DomainInfo
+	for _, result := range c.ValidateDomains(ctx, inputs) {
+		if result.Err == nil {
+			valid = append(valid, result.Info)
+		}
+	}
+	return valid
+}
+
+// ValidateStream validates each input received on inputs and emits the
+// results as they complete, in completion order. The returned channel is
+// closed once inputs is closed and drained, or ctx is cancelled.
+func (c *Client) ValidateStream(ctx context.Context, inputs <-chan string) <-chan DomainResult {
+	return c.validateStream(ctx, inputs, c.concurrency())
+}
+
+func (c *Client) validateStream(ctx context.Context, inputs <-chan string, workers int) <-chan DomainResult {
+	results := make(chan DomainResult)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case input, ok := <-inputs:
+					if !ok {
+						return
+					}
+					select {
+					case results <- c.validateOne(ctx, input):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func (c *Client) validateOne(ctx context.Context, input string) DomainResult {
+	dctx := ctx
+	if c.DomainTimeout > 0 {
+		var cancel context.CancelFunc
+		dctx, cancel = context.WithTimeout(ctx, c.DomainTimeout)
+		defer cancel()
+	}
+
+	info, err := c.ValidateDomain(dctx, input)
+	if err != nil && ctx.Err() == nil && errors.Is(dctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %v: %v", ErrTimeout, c.DomainTimeout, err)
+	}
+	result := DomainResult{Input: input, Info: info, Err: err}
+	if info != nil {
+		result.CleanDomain, result.UnicodeDomain = info.CleanDomain, info.UnicodeDomain
+	} else {
+		result.CleanDomain = c.normalizeDomain(input)
+		result.UnicodeDomain = ToUnicode(result.CleanDomain)
+	}
+	return result
+}
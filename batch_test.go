@@ -0,0 +1,30 @@
+package domaininfo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateDomainsInvalidPunycode(t *testing.T) {
+	const bad = "xn--00a000000000000000000z.com"
+	client := &Client{
+		HostOverrides: map[string]string{bad: "192.0.2.1", "xn--bcher-kva.example": "192.0.2.2"},
+		Providers:     []Provider{TestProvider("fake", &LocationDetails{City: "Berlin"})},
+	}
+
+	results := client.ValidateDomains(context.Background(), []string{bad, "xn--bcher-kva.example"})
+	if len(results) != 2 {
+		t.Fatalf("ValidateDomains returned %d results, want 2", len(results))
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Fatalf("ValidateDomains(%q): %v", result.Input, result.Err)
+		}
+	}
+	if results[0].UnicodeDomain != bad {
+		t.Errorf("UnicodeDomain = %q, want the input unchanged", results[0].UnicodeDomain)
+	}
+	if results[1].UnicodeDomain != "bücher.example" {
+		t.Errorf("UnicodeDomain = %q, want bücher.example", results[1].UnicodeDomain)
+	}
+}
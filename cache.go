@@ -0,0 +1,120 @@
+package domaininfo
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultCacheSize = 10000
+
+type cacheEntry struct {
+	location *LocationDetails
+	expires  time.Time
+}
+
+type geoCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// get returns the cached location for key and whether it is still fresh.
+// Expired entries are kept so they can be served as stale data.
+func (gc *geoCache) get(key string) (*LocationDetails, bool, bool) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	entry, ok := gc.entries[key]
+	if !ok {
+		return nil, false, false
+	}
+
+	return copyLocation(entry.location), time.Now().Before(entry.expires), true
+}
+
+// set stores location under key. When the cache holds size entries, the
+// tenth closest to expiry are evicted first, so a long batch over many
+// distinct IPs cannot grow it without bound.
+func (gc *geoCache) set(key string, location *LocationDetails, ttl time.Duration, size int) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if gc.entries == nil {
+		gc.entries = make(map[string]cacheEntry)
+	}
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+	if _, ok := gc.entries[key]; !ok && len(gc.entries) >= size {
+		gc.evict(max(size/10, 1))
+	}
+
+	gc.entries[key] = cacheEntry{location: copyLocation(location), expires: time.Now().Add(ttl)}
+}
+
+// evict removes the n entries closest to expiry, or already expired the
+// longest.
+func (gc *geoCache) evict(n int) {
+	keys := make([]string, 0, len(gc.entries))
+	for key := range gc.entries {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return gc.entries[a].expires.Compare(gc.entries[b].expires)
+	})
+	for _, key := range keys[:min(n, len(keys))] {
+		delete(gc.entries, key)
+	}
+}
+
+// copyLocation returns a copy of location that shares no slices with it,
+// so callers cannot modify cached entries.
+func copyLocation(location *LocationDetails) *LocationDetails {
+	copied := *location
+	copied.Warnings = slices.Clone(location.Warnings)
+	return &copied
+}
+
+func geoCacheKey(ip, host string) string {
+	if host == "" {
+		return ip
+	}
+	return ip + "|" + host
+}
+
+type negativeEntry struct {
+	err     error
+	expires time.Time
+}
+
+// negativeCache remembers domains that do not exist, so repeated
+// validations fail without another DNS round trip.
+type negativeCache struct {
+	mu      sync.Mutex
+	entries map[string]negativeEntry
+}
+
+func (nc *negativeCache) get(domain string) error {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	entry, ok := nc.entries[domain]
+	if !ok {
+		return nil
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(nc.entries, domain)
+		return nil
+	}
+	return entry.err
+}
+
+func (nc *negativeCache) set(domain string, err error, ttl time.Duration) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if nc.entries == nil {
+		nc.entries = make(map[string]negativeEntry)
+	}
+	nc.entries[domain] = negativeEntry{err: err, expires: time.Now().Add(ttl)}
+}
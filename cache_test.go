@@ -0,0 +1,41 @@
+package domaininfo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGeoCacheEvictsAtSize(t *testing.T) {
+	var gc geoCache
+	for i := 0; i < 25; i++ {
+		gc.set(fmt.Sprint(i), &LocationDetails{City: "Berlin"}, time.Duration(i+1)*time.Minute, 10)
+	}
+	if len(gc.entries) > 10 {
+		t.Fatalf("cache holds %d entries, want at most 10", len(gc.entries))
+	}
+	if _, _, found := gc.get("24"); !found {
+		t.Error("latest entry was evicted")
+	}
+	if _, _, found := gc.get("0"); found {
+		t.Error("entry closest to expiry was kept")
+	}
+}
+
+func TestGeoCacheCopiesWarnings(t *testing.T) {
+	var gc geoCache
+	location := &LocationDetails{Warnings: []string{"original"}}
+	gc.set("192.0.2.1", location, time.Minute, 0)
+	location.Warnings[0] = "changed after set"
+
+	cached, _, _ := gc.get("192.0.2.1")
+	if cached.Warnings[0] != "original" {
+		t.Fatalf("Warnings = %q, want the value at set time", cached.Warnings)
+	}
+	cached.Warnings[0] = "changed after get"
+
+	again, _, _ := gc.get("192.0.2.1")
+	if again.Warnings[0] != "original" {
+		t.Errorf("Warnings = %q, want the cached entry unchanged", again.Warnings)
+	}
+}
@@ -0,0 +1,79 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const maxCNAMEChain = 16
+
+func ResolveChain(domain string) ([]string, []string, error) {
+	return defaultClient.ResolveChain(context.Background(), domain)
+}
+
+// ResolveChain follows the CNAME records of domain and returns each alias
+// target in order, followed by the A and AAAA addresses of the final name.
+// Chains longer than 16 hops return ErrCNAMEChainTooLong together with the
+// chain seen so far, which also guards against loops. A final name that
+// does not exist returns ErrDomainNotFound, also with the chain. domain is
+// normalized as Resolve normalizes it, so IDNs are queried in punycode.
+func (c *Client) ResolveChain(ctx context.Context, domain string) ([]string, []string, error) {
+	name := strings.ToLower(c.normalizeDomain(domain))
+	server := c.dnsServer()
+
+	var chain []string
+	seen := map[string]bool{name: true}
+	for {
+		resp, err := c.exchange(ctx, server, name, dnsTypeA, true)
+		if err != nil {
+			return chain, nil, err
+		}
+
+		// Recursive resolvers usually return the whole chain in one answer,
+		// even when its final target does not exist.
+		hops := len(chain)
+		for {
+			target := ""
+			for _, record := range resp.Answers {
+				if record.Type == dnsTypeCNAME && record.Name == name {
+					target = record.Data
+					break
+				}
+			}
+			if target == "" {
+				break
+			}
+			if len(chain) == maxCNAMEChain || seen[target] {
+				return chain, nil, ErrCNAMEChainTooLong
+			}
+			chain = append(chain, target)
+			seen[target] = true
+			name = target
+		}
+		if resp.RCode == 3 {
+			return chain, nil, fmt.Errorf("%w: resolving %s: NXDOMAIN", ErrDomainNotFound, name)
+		}
+		if resp.RCode != 0 {
+			return chain, nil, fmt.Errorf("resolving %s: %s", name, rcodeName(resp.RCode))
+		}
+
+		ips := recordData(resp.Answers, name, dnsTypeA)
+		if aaaa, err := c.exchange(ctx, server, name, dnsTypeAAAA, true); err == nil {
+			ips = append(ips, recordData(aaaa.Answers, name, dnsTypeAAAA)...)
+		}
+		if len(ips) > 0 || len(chain) == hops {
+			return chain, ips, nil
+		}
+	}
+}
+
+func recordData(records []dnsRecord, name string, rtype uint16) []string {
+	var data []string
+	for _, record := range records {
+		if record.Type == rtype && record.Name == name {
+			data = append(data, record.Data)
+		}
+	}
+	return data
+}
@@ -0,0 +1,25 @@
+package domaininfo
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestResolveChainNormalizesIDN(t *testing.T) {
+	server, names := fakeDNSServer(t, net.ParseIP("192.0.2.7"))
+	client := &Client{Resolvers: []string{server}}
+
+	chain, ips, err := client.ResolveChain(context.Background(), "https://www.Bücher.Example/")
+	if err != nil {
+		t.Fatalf("ResolveChain: %v", err)
+	}
+	if len(chain) != 0 || len(ips) == 0 || ips[0] != "192.0.2.7" {
+		t.Errorf("ResolveChain = %v, %v, want no aliases and 192.0.2.7", chain, ips)
+	}
+	for _, name := range names() {
+		if name != "xn--bcher-kva.example" {
+			t.Errorf("queried %q, want xn--bcher-kva.example", name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package domaininfo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+)
+
+type ipRanges []*net.IPNet
+
+func parseIPRanges(cidrs []string) ipRanges {
+	var ranges ipRanges
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err == nil {
+			ranges = append(ranges, network)
+		}
+	}
+	return ranges
+}
+
+// parseIPRangeList parses one CIDR per line, ignoring blank lines and
+// comments.
+func parseIPRangeList(data []byte) ipRanges {
+	var cidrs []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			cidrs = append(cidrs, line)
+		}
+	}
+	return parseIPRanges(cidrs)
+}
+
+func (r ipRanges) contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range r {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkExpectedRanges returns ErrOutsideExpectedRange, naming the
+// offending addresses, when any of ips falls outside every CIDR in
+// Client.ExpectedCIDRs. An entry that is not a valid CIDR is reported as a
+// configuration error instead.
+func (c *Client) checkExpectedRanges(ips []string) error {
+	if len(c.ExpectedCIDRs) == 0 {
+		return nil
+	}
+
+	// Parse strictly: silently dropping a mistyped entry would make
+	// legitimate addresses fail the check, or hide real drift.
+	var ranges ipRanges
+	for _, cidr := range c.ExpectedCIDRs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return fmt.Errorf("invalid ExpectedCIDRs entry %q: %v", cidr, err)
+		}
+		ranges = append(ranges, network)
+	}
+
+	var outside []string
+	for _, ip := range ips {
+		if !ranges.contains(net.ParseIP(ip)) {
+			outside = append(outside, ip)
+		}
+	}
+	if len(outside) > 0 {
+		return fmt.Errorf("%w: %s", ErrOutsideExpectedRange, strings.Join(outside, ", "))
+	}
+	return nil
+}
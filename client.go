@@ -0,0 +1,340 @@
+package domaininfo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Client holds the configuration used for lookups. The zero value is ready
+// to use and behaves like the package-level functions.
+type Client struct {
+	HTTPClient *http.Client
+	Resolver   *net.Resolver
+	Tracer     Tracer
+	Retry      RetryPolicy
+
+	// Timeout bounds each ValidateDomain, Resolve and Enrich call, in
+	// addition to any deadline on the context. Zero means no limit.
+	Timeout time.Duration
+
+	// CentroidFallback fills missing coordinates with the country centroid
+	// when a provider only reports the country.
+	CentroidFallback bool
+
+	// CacheTTL caches geolocation results per IP for this long. Zero
+	// disables caching.
+	CacheTTL time.Duration
+
+	// CacheSize caps the number of cached locations. When it is reached,
+	// the entries closest to expiry are evicted first. Zero uses 10000.
+	CacheSize int
+
+	// NegativeCacheTTL remembers domains that returned NXDOMAIN for this
+	// long, failing repeated validations without another DNS lookup.
+	// Transient failures such as timeouts are never cached. Zero disables
+	// negative caching.
+	NegativeCacheTTL time.Duration
+
+	// StaleOnError returns the last cached location for an IP, even an
+	// expired one, when every provider fails. Such results have Stale set.
+	StaleOnError bool
+
+	// RateLimit caps geolocation provider requests per second across all
+	// lookups made by the client. Zero means unlimited.
+	RateLimit float64
+
+	// RecordTiming fills DomainInfo.Timing with per-phase durations.
+	RecordTiming bool
+
+	// TyposquatThreshold is the maximum edit distance DetectTyposquat
+	// reports as a match. Zero uses the default of 2.
+	TyposquatThreshold int
+
+	// Concurrency is the number of workers used by the batch functions.
+	// Zero uses the default of 8.
+	Concurrency int
+
+	// MaxGeoIPs caps how many unique IPs LocateAll geolocates, taking the
+	// first ones in resolution order, to bound cost for CDN domains that
+	// return dozens of addresses. Zero locates all of them.
+	MaxGeoIPs int
+
+	// DomainTimeout bounds the total time the batch functions spend on each
+	// domain, so one slow domain cannot hold a worker for long. Domains that
+	// run out of time report ErrTimeout. Zero means no per-domain limit.
+	DomainTimeout time.Duration
+
+	// Progress, when set, is called by ValidateDomains after each domain
+	// finishes with the number completed so far and the batch size. Calls
+	// are serialized, so the callback need not be safe for concurrent use.
+	Progress func(completed, total int)
+
+	// Providers replaces the geolocation providers, tried in order. Nil
+	// uses DefaultProviders.
+	Providers []Provider
+
+	// ProviderParams adds query parameters, such as an API key, field
+	// selection or language, to the requests sent to each provider, keyed by
+	// provider name. Errors and status reports use the URL without them.
+	ProviderParams map[string]map[string]string
+
+	// ReliabilityOrder tries providers in descending order of their
+	// observed success rate, recomputed every 30 seconds, instead of the
+	// configured order.
+	ReliabilityOrder bool
+
+	// RequiredFields lists LocationDetails fields, by Go or JSON name, that
+	// a provider's result must fill to be accepted; otherwise the next
+	// provider is tried. Nil accepts any result with a city or country.
+	RequiredFields []string
+
+	// Strategy selects how Providers are queried. The default,
+	// StrategyFirst, returns the first successful answer.
+	Strategy ProviderStrategy
+
+	// HedgeDelay is how long StrategyHedged waits for a provider before
+	// starting the next one. Zero uses the default of 500ms.
+	HedgeDelay time.Duration
+
+	// CoordinateAgreementKm is the distance within which StrategyMerge
+	// averages provider coordinates. Zero uses the default of 100 km.
+	CoordinateAgreementKm float64
+
+	// DeadlineAware skips a provider when its typical latency, tracked per
+	// client and reported by ProviderLatency, would not fit in the time
+	// left before the context deadline.
+	DeadlineAware bool
+
+	// DoHURL, when set, resolves A and AAAA records through this
+	// DNS-over-HTTPS JSON endpoint instead of the system resolver.
+	DoHURL string
+
+	// DNSTimeout bounds each query made by the built-in DNS client, used by
+	// ResolveChain, ResolveStatus, CheckDanglingCNAME and authoritative
+	// lookups, even when the context has no deadline. Zero uses the default
+	// of 5 seconds.
+	DNSTimeout time.Duration
+
+	// Resolvers lists DNS servers ("host" or "host:port") tried in order.
+	// The next server is used when one times out or fails; an NXDOMAIN
+	// answer is final. The system resolver is used when empty.
+	Resolvers []string
+
+	// QueryAuthoritative also asks the domain's authoritative nameservers,
+	// found through NS lookups, for its addresses without recursion and
+	// records whether they match the recursive answer.
+	QueryAuthoritative bool
+
+	// ExpectedCIDRs, when non-empty, lists the only ranges the domain may
+	// resolve into. Resolution fails with ErrOutsideExpectedRange, naming
+	// every address outside all of them, so unexpected DNS changes surface.
+	// An entry that does not parse as a CIDR fails every resolution with an
+	// error naming it.
+	ExpectedCIDRs []string
+
+	// HostOverrides maps domains to fixed IPs, like an /etc/hosts file
+	// private to the client. Overridden domains skip DNS entirely.
+	HostOverrides map[string]string
+
+	// AllowedTLDs restricts validation to these public suffixes when
+	// non-empty. BlockedTLDs rejects them. An entry also matches longer
+	// suffixes ending in it, so "uk" covers "co.uk".
+	AllowedTLDs []string
+	BlockedTLDs []string
+
+	// AllowPort accepts endpoint strings such as "example.com:8080" or
+	// "https://example.com:8443/": the port is validated separately,
+	// failing with ErrInvalidPort outside 1-65535, and stored in
+	// DomainInfo.Port while the host is validated as usual.
+	AllowPort bool
+
+	// CleanHooks preprocess raw input, in order, before the built-in
+	// cleaning, for quirks such as surrounding quotes or tracking
+	// parameters.
+	CleanHooks []func(string) string
+
+	// Canonicalize lowercases the cleaned domain and strips a trailing dot
+	// and any remaining www prefix, so www and apex inputs produce the same
+	// CleanDomain.
+	Canonicalize bool
+
+	// GeoByHostname sends the domain rather than the locally resolved IP to
+	// providers that can resolve hostnames themselves (ipapi, ipinfo). The
+	// provider's resolution may differ from ours, for example under ECS.
+	GeoByHostname bool
+
+	// LocalAddr binds outgoing DNS, HTTP and WHOIS connections to this
+	// local address, for multi-homed hosts. Only its IP is used. It does
+	// not apply to a caller-supplied HTTPClient or Resolver.
+	LocalAddr net.Addr
+
+	// WHOISServers overrides the WHOIS server used for a TLD, keyed by the
+	// TLD without a leading dot. It takes precedence over the bundled map.
+	WHOISServers map[string]string
+
+	// WHOISTimeout bounds each WHOIS query and RDAP request, separately
+	// from the geolocation settings, since registries are often far
+	// slower. Zero leaves only the context deadline and HTTPClient timeout.
+	WHOISTimeout time.Duration
+
+	// WHOISRetries is the number of times a WHOIS query or RDAP request
+	// that failed transiently is retried, using the Retry backoff. Zero
+	// uses Retry.MaxRetries.
+	WHOISRetries int
+
+	// RiskSignals selects the signals evaluated by RiskScore. Nil enables
+	// all of them.
+	RiskSignals []RiskSignal
+
+	// PassiveDNSURL is the base URL of a passive DNS service used by
+	// HistoricalIPs; the domain is appended as a path segment. PassiveDNSKey
+	// is sent in the X-API-Key header when set.
+	PassiveDNSURL string
+	PassiveDNSKey string
+
+	// ReverseIPURL is the base URL of a reverse-IP or passive DNS service
+	// used by NeighborDomains; the IP is appended as a path segment.
+	// ReverseIPKey, sent in the X-API-Key header, is required alongside it.
+	ReverseIPURL string
+	ReverseIPKey string
+
+	// FixtureFile names a JSON file mapping domains to recorded DomainInfo
+	// results, including Location, such as DomainInfo values marshaled
+	// from earlier runs. When set, Resolve and ValidateDomain answer from it
+	// without network access, for deterministic tests and offline demos.
+	// Domains missing from it fail with ErrNotInFixture unless
+	// FixtureFallthrough is set, in which case they are looked up live.
+	FixtureFile        string
+	FixtureFallthrough bool
+
+	boundHTTPOnce sync.Once
+	boundHTTP     *http.Client
+
+	quotas          sync.Map
+	stats           sync.Map
+	reliability     reliabilitySnapshot
+	whoisDiscovered sync.Map
+	geoCache        geoCache
+	negCache        negativeCache
+	geoLimiter      rateLimiter
+	geoFlight       flightGroup
+	retryBudget     retryBudget
+	fixtures        fixtures
+}
+
+var defaultClient = &Client{}
+
+// SetDefaultTimeout sets the Timeout used by the package-level functions.
+// Like SetDefaultHTTPClient, it should be called during initialization,
+// before any lookups run.
+func SetDefaultTimeout(d time.Duration) {
+	defaultClient.Timeout = d
+}
+
+// SetDefaultHTTPClient sets the HTTP client used by the package-level
+// functions in place of http.DefaultClient.
+func SetDefaultHTTPClient(hc *http.Client) {
+	defaultClient.HTTPClient = hc
+}
+
+// withTimeout applies Client.Timeout to ctx.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout > 0 {
+		return context.WithTimeout(ctx, c.Timeout)
+	}
+	return ctx, func() {}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	if c.LocalAddr != nil {
+		return c.boundHTTPClient()
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) resolver() *net.Resolver {
+	if c.Resolver != nil {
+		return c.Resolver
+	}
+	if c.LocalAddr != nil {
+		return c.boundResolver()
+	}
+	return net.DefaultResolver
+}
+
+func (c *Client) fetch(ctx context.Context, provider, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if params := c.ProviderParams[provider]; provider != "" && len(params) > 0 {
+		query := req.URL.Query()
+		for key, value := range params {
+			query.Set(key, value)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		// Report the URL without ProviderParams, which may hold API keys.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = rawURL
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c.recordQuota(provider, resp.Header)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, URL: rawURL}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Some providers answer 200 with an empty body under load.
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("%w from %s", ErrEmptyResponse, rawURL)
+	}
+	return body, nil
+}
+
+func isTransientDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		return false
+	}
+	return !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+}
+
+func classifyDNSError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		switch {
+		case dnsErr.IsNotFound:
+			return fmt.Errorf("%w: %v", ErrDomainNotFound, err)
+		case dnsErr.IsTimeout:
+			return fmt.Errorf("%w: %v", ErrDNSTimeout, err)
+		}
+	}
+
+	return fmt.Errorf("cannot resolve domain: %w", err)
+}
@@ -0,0 +1,219 @@
+package domaininfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync/atomic"
+)
+
+var cloudProviderOrder = []string{"AWS", "GCP", "Azure", "DigitalOcean"}
+
+// bundledCloudRanges is a representative snapshot of the largest published
+// prefixes of each provider. RefreshCloudRanges loads the complete lists.
+var bundledCloudRanges = map[string][]string{
+	"AWS": {
+		"3.0.0.0/9", "3.128.0.0/9", "13.32.0.0/15", "13.224.0.0/14",
+		"15.177.0.0/16", "18.128.0.0/9", "34.192.0.0/10", "44.192.0.0/10",
+		"52.0.0.0/11", "52.32.0.0/11", "52.94.0.0/16", "54.144.0.0/12",
+		"54.160.0.0/11", "54.192.0.0/12", "99.77.0.0/16", "2600:1f00::/24",
+	},
+	"GCP": {
+		"23.236.48.0/20", "34.64.0.0/10", "34.128.0.0/10", "35.184.0.0/13",
+		"35.192.0.0/12", "35.208.0.0/12", "35.224.0.0/12", "35.240.0.0/13",
+		"104.154.0.0/15", "104.196.0.0/14", "107.178.192.0/18",
+		"130.211.0.0/16", "146.148.0.0/17", "2600:1900::/28",
+	},
+	"Azure": {
+		"13.64.0.0/11", "20.33.0.0/16", "20.36.0.0/14", "20.40.0.0/13",
+		"23.96.0.0/13", "40.64.0.0/10", "52.224.0.0/11", "104.40.0.0/13",
+		"137.116.0.0/15", "168.61.0.0/16", "191.232.0.0/13",
+	},
+	"DigitalOcean": {
+		"46.101.0.0/16", "64.225.0.0/17", "68.183.0.0/16", "104.131.0.0/16",
+		"104.236.0.0/16", "107.170.0.0/16", "128.199.0.0/16", "134.209.0.0/16",
+		"137.184.0.0/16", "138.68.0.0/16", "138.197.0.0/16", "139.59.0.0/16",
+		"142.93.0.0/16", "143.110.128.0/17", "146.190.0.0/16", "157.230.0.0/16",
+		"159.65.0.0/16", "159.89.0.0/16", "161.35.0.0/16", "164.90.128.0/17",
+		"165.227.0.0/16", "167.71.0.0/16", "167.99.0.0/16", "174.138.0.0/17",
+		"178.62.0.0/17", "188.166.0.0/16", "206.189.0.0/16", "209.97.128.0/18",
+	},
+}
+
+// CloudRangeURLs lists where RefreshCloudRanges fetches each provider's
+// published ranges. Azure publishes its service tags file under a URL that
+// changes weekly, so it is empty by default and skipped until set.
+var CloudRangeURLs = map[string]string{
+	"AWS":          "https://ip-ranges.amazonaws.com/ip-ranges.json",
+	"GCP":          "https://www.gstatic.com/ipranges/cloud.json",
+	"Azure":        "",
+	"DigitalOcean": "https://digitalocean.com/geo/google.csv",
+}
+
+var cloudRangeParsers = map[string]func([]byte) (ipRanges, error){
+	"AWS":          parseAWSRanges,
+	"GCP":          parseGCPRanges,
+	"Azure":        parseAzureRanges,
+	"DigitalOcean": parseCSVRanges,
+}
+
+var cloudRanges atomic.Pointer[map[string]ipRanges]
+
+func init() {
+	ranges := make(map[string]ipRanges)
+	for provider, cidrs := range bundledCloudRanges {
+		ranges[provider] = parseIPRanges(cidrs)
+	}
+	cloudRanges.Store(&ranges)
+}
+
+// CloudProvider reports which cloud provider (AWS, GCP, Azure or
+// DigitalOcean) hosts the resolved IPs of info, based on the providers'
+// published CIDR ranges.
+func CloudProvider(info *DomainInfo) (string, bool) {
+	if info == nil {
+		return "", false
+	}
+
+	ips := info.IPAddresses
+	if len(ips) == 0 {
+		ips = []string{info.IPAddress}
+	}
+
+	ranges := *cloudRanges.Load()
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		for _, provider := range cloudProviderOrder {
+			if ranges[provider].contains(parsed) {
+				return provider, true
+			}
+		}
+	}
+	return "", false
+}
+
+func RefreshCloudRanges(ctx context.Context) error {
+	return defaultClient.RefreshCloudRanges(ctx)
+}
+
+// RefreshCloudRanges downloads the ranges listed in CloudRangeURLs and
+// replaces the tables used by CloudProvider. Providers whose download fails
+// keep their previous ranges, and the first error is returned.
+func (c *Client) RefreshCloudRanges(ctx context.Context) error {
+	current := *cloudRanges.Load()
+	updated := make(map[string]ipRanges, len(current))
+	for provider, ranges := range current {
+		updated[provider] = ranges
+	}
+
+	var firstErr error
+	for provider, rawURL := range CloudRangeURLs {
+		parse, ok := cloudRangeParsers[provider]
+		if rawURL == "" || !ok {
+			continue
+		}
+
+		body, err := c.fetch(ctx, "", rawURL)
+		if err == nil {
+			var ranges ipRanges
+			if ranges, err = parse(body); err == nil && len(ranges) > 0 {
+				updated[provider] = ranges
+				continue
+			}
+		}
+		if firstErr == nil {
+			if err == nil {
+				err = fmt.Errorf("no ranges found")
+			}
+			firstErr = fmt.Errorf("refreshing %s ranges: %v", provider, err)
+		}
+	}
+
+	cloudRanges.Store(&updated)
+	return firstErr
+}
+
+func parseAWSRanges(data []byte) (ipRanges, error) {
+	var doc struct {
+		Prefixes []struct {
+			IPPrefix string `json:"ip_prefix"`
+		} `json:"prefixes"`
+		IPv6Prefixes []struct {
+			IPv6Prefix string `json:"ipv6_prefix"`
+		} `json:"ipv6_prefixes"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var cidrs []string
+	for _, p := range doc.Prefixes {
+		cidrs = append(cidrs, p.IPPrefix)
+	}
+	for _, p := range doc.IPv6Prefixes {
+		cidrs = append(cidrs, p.IPv6Prefix)
+	}
+	return parseIPRanges(cidrs), nil
+}
+
+func parseGCPRanges(data []byte) (ipRanges, error) {
+	var doc struct {
+		Prefixes []struct {
+			IPv4Prefix string `json:"ipv4Prefix"`
+			IPv6Prefix string `json:"ipv6Prefix"`
+		} `json:"prefixes"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var cidrs []string
+	for _, p := range doc.Prefixes {
+		if p.IPv4Prefix != "" {
+			cidrs = append(cidrs, p.IPv4Prefix)
+		}
+		if p.IPv6Prefix != "" {
+			cidrs = append(cidrs, p.IPv6Prefix)
+		}
+	}
+	return parseIPRanges(cidrs), nil
+}
+
+func parseAzureRanges(data []byte) (ipRanges, error) {
+	var doc struct {
+		Values []struct {
+			Properties struct {
+				AddressPrefixes []string `json:"addressPrefixes"`
+			} `json:"properties"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var cidrs []string
+	for _, v := range doc.Values {
+		cidrs = append(cidrs, v.Properties.AddressPrefixes...)
+	}
+	return parseIPRanges(cidrs), nil
+}
+
+func parseCSVRanges(data []byte) (ipRanges, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var cidrs []string
+	for _, record := range records {
+		if len(record) > 0 {
+			cidrs = append(cidrs, record[0])
+		}
+	}
+	return parseIPRanges(cidrs), nil
+}
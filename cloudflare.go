@@ -0,0 +1,75 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+)
+
+// Published at https://www.cloudflare.com/ips/.
+var bundledCloudflareRanges = []string{
+	"173.245.48.0/20",
+	"103.21.244.0/22",
+	"103.22.200.0/22",
+	"103.31.4.0/22",
+	"141.101.64.0/18",
+	"108.162.192.0/18",
+	"190.93.240.0/20",
+	"188.114.96.0/20",
+	"197.234.240.0/22",
+	"198.41.128.0/17",
+	"162.158.0.0/15",
+	"104.16.0.0/13",
+	"104.24.0.0/14",
+	"172.64.0.0/13",
+	"131.0.72.0/22",
+	"2400:cb00::/32",
+	"2606:4700::/32",
+	"2803:f800::/32",
+	"2405:b500::/32",
+	"2405:8100::/32",
+	"2a06:98c0::/29",
+	"2c0f:f248::/32",
+}
+
+var cloudflareRangeURLs = []string{
+	"https://www.cloudflare.com/ips-v4",
+	"https://www.cloudflare.com/ips-v6",
+}
+
+var cloudflareRanges atomic.Pointer[ipRanges]
+
+func init() {
+	ranges := parseIPRanges(bundledCloudflareRanges)
+	cloudflareRanges.Store(&ranges)
+}
+
+// IsCloudflareIP reports whether ip belongs to Cloudflare's published
+// ranges, meaning it is a Cloudflare edge rather than the origin server.
+func IsCloudflareIP(ip string) bool {
+	return cloudflareRanges.Load().contains(net.ParseIP(ip))
+}
+
+func RefreshCloudflareRanges(ctx context.Context) error {
+	return defaultClient.RefreshCloudflareRanges(ctx)
+}
+
+// RefreshCloudflareRanges replaces the bundled Cloudflare ranges with the
+// lists currently published by Cloudflare.
+func (c *Client) RefreshCloudflareRanges(ctx context.Context) error {
+	var ranges ipRanges
+	for _, rawURL := range cloudflareRangeURLs {
+		body, err := c.fetch(ctx, "", rawURL)
+		if err != nil {
+			return fmt.Errorf("fetching Cloudflare ranges: %v", err)
+		}
+		ranges = append(ranges, parseIPRangeList(body)...)
+	}
+
+	if len(ranges) == 0 {
+		return fmt.Errorf("no Cloudflare ranges found")
+	}
+	cloudflareRanges.Store(&ranges)
+	return nil
+}
@@ -0,0 +1,41 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+func AreColocated(ip1, ip2 string, thresholdKm float64) (bool, error) {
+	return defaultClient.AreColocated(context.Background(), ip1, ip2, thresholdKm)
+}
+
+// AreColocated geolocates both IPs, through the client's cache, and reports
+// whether they lie within thresholdKm of each other. It helps collapse the
+// many edge IPs of one CDN into logical locations. When either IP cannot be
+// geolocated, the lookup error is returned; a location without usable
+// coordinates yields ErrNoCoordinates.
+func (c *Client) AreColocated(ctx context.Context, ip1, ip2 string, thresholdKm float64) (bool, error) {
+	a, b := net.ParseIP(ip1), net.ParseIP(ip2)
+	if a == nil || b == nil {
+		return false, ErrInvalidIP
+	}
+	if a.Equal(b) {
+		return true, nil
+	}
+
+	locations, errs := c.LocateIPs(ctx, []string{a.String(), b.String()}, 2)
+	var coords [2][2]float64
+	for i, ip := range []string{a.String(), b.String()} {
+		if err := errs[ip]; err != nil {
+			return false, fmt.Errorf("locating %s: %w", ip, err)
+		}
+		location := locations[ip]
+		if !validCoordinates(location.Latitude, location.Longitude) {
+			return false, fmt.Errorf("%w: %s", ErrNoCoordinates, ip)
+		}
+		coords[i] = [2]float64{location.Latitude, location.Longitude}
+	}
+
+	return haversineKm(coords[0][0], coords[0][1], coords[1][0], coords[1][1]) <= thresholdKm, nil
+}
@@ -0,0 +1,40 @@
+package domaininfo
+
+import "strings"
+
+var continentNames = map[string]string{
+	"AF": "Africa",
+	"AN": "Antarctica",
+	"AS": "Asia",
+	"EU": "Europe",
+	"NA": "North America",
+	"OC": "Oceania",
+	"SA": "South America",
+}
+
+// euCountries is the ISO 3166-1 alpha-2 set of European Union member
+// states.
+var euCountries = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "CY": true, "CZ": true, "DE": true,
+	"DK": true, "EE": true, "ES": true, "FI": true, "FR": true, "GR": true,
+	"HR": true, "HU": true, "IE": true, "IT": true, "LT": true, "LU": true,
+	"LV": true, "MT": true, "NL": true, "PL": true, "PT": true, "RO": true,
+	"SE": true, "SI": true, "SK": true,
+}
+
+// IsEU reports whether the location's country is a European Union member
+// state. Note that the EU is narrower than the EU continent code.
+func (l *LocationDetails) IsEU() bool {
+	code := l.CountryCode
+	if code == "" && len(l.Country) == 2 {
+		code = l.Country
+	}
+	return euCountries[strings.ToUpper(code)]
+}
+
+func fillContinent(location *LocationDetails) {
+	location.ContinentCode = strings.ToUpper(location.ContinentCode)
+	if location.Continent == "" {
+		location.Continent = continentNames[location.ContinentCode]
+	}
+}
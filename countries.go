@@ -0,0 +1,269 @@
+package domaininfo
+
+import "strings"
+
+// countryNames maps lowercase English country names, including the ISO
+// 3166 formal names and common aliases that WHOIS records and providers
+// use, to ISO 3166-1 alpha-2 codes.
+var countryNames = map[string]string{
+	"afghanistan":                            "AF",
+	"albania":                                "AL",
+	"algeria":                                "DZ",
+	"america":                                "US",
+	"andorra":                                "AD",
+	"angola":                                 "AO",
+	"antigua and barbuda":                    "AG",
+	"argentina":                              "AR",
+	"armenia":                                "AM",
+	"australia":                              "AU",
+	"austria":                                "AT",
+	"azerbaijan":                             "AZ",
+	"bahamas":                                "BS",
+	"bahrain":                                "BH",
+	"bangladesh":                             "BD",
+	"barbados":                               "BB",
+	"belarus":                                "BY",
+	"belgium":                                "BE",
+	"belize":                                 "BZ",
+	"benin":                                  "BJ",
+	"bhutan":                                 "BT",
+	"bolivia":                                "BO",
+	"bolivia, plurinational state of":        "BO",
+	"bosnia and herzegovina":                 "BA",
+	"botswana":                               "BW",
+	"brazil":                                 "BR",
+	"brunei":                                 "BN",
+	"brunei darussalam":                      "BN",
+	"bulgaria":                               "BG",
+	"burkina faso":                           "BF",
+	"burma":                                  "MM",
+	"burundi":                                "BI",
+	"cabo verde":                             "CV",
+	"cambodia":                               "KH",
+	"cameroon":                               "CM",
+	"canada":                                 "CA",
+	"cape verde":                             "CV",
+	"central african republic":               "CF",
+	"chad":                                   "TD",
+	"chile":                                  "CL",
+	"china":                                  "CN",
+	"colombia":                               "CO",
+	"comoros":                                "KM",
+	"congo":                                  "CG",
+	"congo, the democratic republic of the":  "CD",
+	"costa rica":                             "CR",
+	"cote d'ivoire":                          "CI",
+	"croatia":                                "HR",
+	"cuba":                                   "CU",
+	"cyprus":                                 "CY",
+	"czech republic":                         "CZ",
+	"czechia":                                "CZ",
+	"côte d'ivoire":                          "CI",
+	"democratic republic of the congo":       "CD",
+	"denmark":                                "DK",
+	"djibouti":                               "DJ",
+	"dominica":                               "DM",
+	"dominican republic":                     "DO",
+	"east timor":                             "TL",
+	"ecuador":                                "EC",
+	"egypt":                                  "EG",
+	"el salvador":                            "SV",
+	"england":                                "GB",
+	"equatorial guinea":                      "GQ",
+	"eritrea":                                "ER",
+	"estonia":                                "EE",
+	"eswatini":                               "SZ",
+	"ethiopia":                               "ET",
+	"fiji":                                   "FJ",
+	"finland":                                "FI",
+	"france":                                 "FR",
+	"gabon":                                  "GA",
+	"gambia":                                 "GM",
+	"georgia":                                "GE",
+	"germany":                                "DE",
+	"ghana":                                  "GH",
+	"great britain":                          "GB",
+	"greece":                                 "GR",
+	"grenada":                                "GD",
+	"guatemala":                              "GT",
+	"guinea":                                 "GN",
+	"guinea-bissau":                          "GW",
+	"guyana":                                 "GY",
+	"haiti":                                  "HT",
+	"holland":                                "NL",
+	"holy see":                               "VA",
+	"honduras":                               "HN",
+	"hong kong":                              "HK",
+	"hong kong sar":                          "HK",
+	"hungary":                                "HU",
+	"iceland":                                "IS",
+	"india":                                  "IN",
+	"indonesia":                              "ID",
+	"iran":                                   "IR",
+	"iran, islamic republic of":              "IR",
+	"iraq":                                   "IQ",
+	"ireland":                                "IE",
+	"israel":                                 "IL",
+	"italy":                                  "IT",
+	"ivory coast":                            "CI",
+	"jamaica":                                "JM",
+	"japan":                                  "JP",
+	"jordan":                                 "JO",
+	"kazakhstan":                             "KZ",
+	"kenya":                                  "KE",
+	"kiribati":                               "KI",
+	"korea":                                  "KR",
+	"korea, democratic people's republic of": "KP",
+	"korea, republic of":                     "KR",
+	"kosovo":                                 "XK",
+	"kuwait":                                 "KW",
+	"kyrgyzstan":                             "KG",
+	"lao people's democratic republic":       "LA",
+	"laos":                                   "LA",
+	"latvia":                                 "LV",
+	"lebanon":                                "LB",
+	"lesotho":                                "LS",
+	"liberia":                                "LR",
+	"libya":                                  "LY",
+	"liechtenstein":                          "LI",
+	"lithuania":                              "LT",
+	"luxembourg":                             "LU",
+	"macao":                                  "MO",
+	"macau":                                  "MO",
+	"macedonia":                              "MK",
+	"madagascar":                             "MG",
+	"malawi":                                 "MW",
+	"malaysia":                               "MY",
+	"maldives":                               "MV",
+	"mali":                                   "ML",
+	"malta":                                  "MT",
+	"marshall islands":                       "MH",
+	"mauritania":                             "MR",
+	"mauritius":                              "MU",
+	"mexico":                                 "MX",
+	"micronesia":                             "FM",
+	"micronesia, federated states of":        "FM",
+	"moldova":                                "MD",
+	"moldova, republic of":                   "MD",
+	"monaco":                                 "MC",
+	"mongolia":                               "MN",
+	"montenegro":                             "ME",
+	"morocco":                                "MA",
+	"mozambique":                             "MZ",
+	"myanmar":                                "MM",
+	"namibia":                                "NA",
+	"nauru":                                  "NR",
+	"nepal":                                  "NP",
+	"netherlands":                            "NL",
+	"new zealand":                            "NZ",
+	"nicaragua":                              "NI",
+	"niger":                                  "NE",
+	"nigeria":                                "NG",
+	"north korea":                            "KP",
+	"north macedonia":                        "MK",
+	"norway":                                 "NO",
+	"oman":                                   "OM",
+	"pakistan":                               "PK",
+	"palau":                                  "PW",
+	"palestine":                              "PS",
+	"palestine, state of":                    "PS",
+	"panama":                                 "PA",
+	"papua new guinea":                       "PG",
+	"paraguay":                               "PY",
+	"peru":                                   "PE",
+	"philippines":                            "PH",
+	"poland":                                 "PL",
+	"portugal":                               "PT",
+	"puerto rico":                            "PR",
+	"qatar":                                  "QA",
+	"republic of korea":                      "KR",
+	"republic of the congo":                  "CG",
+	"romania":                                "RO",
+	"russia":                                 "RU",
+	"russian federation":                     "RU",
+	"rwanda":                                 "RW",
+	"saint kitts and nevis":                  "KN",
+	"saint lucia":                            "LC",
+	"saint vincent and the grenadines":       "VC",
+	"samoa":                                  "WS",
+	"san marino":                             "SM",
+	"sao tome and principe":                  "ST",
+	"saudi arabia":                           "SA",
+	"senegal":                                "SN",
+	"serbia":                                 "RS",
+	"seychelles":                             "SC",
+	"sierra leone":                           "SL",
+	"singapore":                              "SG",
+	"slovakia":                               "SK",
+	"slovenia":                               "SI",
+	"solomon islands":                        "SB",
+	"somalia":                                "SO",
+	"south africa":                           "ZA",
+	"south korea":                            "KR",
+	"south sudan":                            "SS",
+	"spain":                                  "ES",
+	"sri lanka":                              "LK",
+	"sudan":                                  "SD",
+	"suriname":                               "SR",
+	"swaziland":                              "SZ",
+	"sweden":                                 "SE",
+	"switzerland":                            "CH",
+	"syria":                                  "SY",
+	"syrian arab republic":                   "SY",
+	"taiwan":                                 "TW",
+	"taiwan, province of china":              "TW",
+	"tajikistan":                             "TJ",
+	"tanzania":                               "TZ",
+	"tanzania, united republic of":           "TZ",
+	"thailand":                               "TH",
+	"the bahamas":                            "BS",
+	"the gambia":                             "GM",
+	"the netherlands":                        "NL",
+	"timor-leste":                            "TL",
+	"togo":                                   "TG",
+	"tonga":                                  "TO",
+	"trinidad and tobago":                    "TT",
+	"tunisia":                                "TN",
+	"turkey":                                 "TR",
+	"turkiye":                                "TR",
+	"turkmenistan":                           "TM",
+	"tuvalu":                                 "TV",
+	"türkiye":                                "TR",
+	"u.s.":                                   "US",
+	"u.s.a.":                                 "US",
+	"uae":                                    "AE",
+	"uganda":                                 "UG",
+	"uk":                                     "GB",
+	"ukraine":                                "UA",
+	"united arab emirates":                   "AE",
+	"united kingdom":                         "GB",
+	"united kingdom of great britain and northern ireland": "GB",
+	"united states":                     "US",
+	"united states of america":          "US",
+	"uruguay":                           "UY",
+	"usa":                               "US",
+	"uzbekistan":                        "UZ",
+	"vanuatu":                           "VU",
+	"vatican city":                      "VA",
+	"venezuela":                         "VE",
+	"venezuela, bolivarian republic of": "VE",
+	"viet nam":                          "VN",
+	"vietnam":                           "VN",
+	"yemen":                             "YE",
+	"zambia":                            "ZM",
+	"zimbabwe":                          "ZW",
+}
+
+// countryCode converts an ISO code or country name to its uppercase ISO
+// 3166-1 alpha-2 code, or returns "" when country is not recognized. "UK"
+// is accepted for GB, as registries often write it.
+func countryCode(country string) string {
+	country = strings.TrimSpace(country)
+	if code, ok := countryNames[strings.ToLower(country)]; ok {
+		return code
+	}
+	if len(country) == 2 {
+		return strings.ToUpper(country)
+	}
+	return ""
+}
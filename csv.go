@@ -0,0 +1,103 @@
+package domaininfo
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var csvColumns = []string{"ip", "city", "country", "error"}
+
+// csvHeaderNames are the domain column names that mark a first row as a
+// header. Matching names rather than rejecting invalid domains keeps an
+// invalid first domain from being mistaken for a header.
+var csvHeaderNames = map[string]bool{
+	"domain":   true,
+	"domains":  true,
+	"host":     true,
+	"hostname": true,
+	"url":      true,
+	"website":  true,
+}
+
+func ValidateCSV(in io.Reader, out io.Writer, domainColumn int) error {
+	return defaultClient.ValidateCSV(context.Background(), in, out, domainColumn)
+}
+
+// ValidateCSV validates the domain in column domainColumn (zero-based) of
+// every row of in and writes each row to out with ip, city, country and
+// error columns appended. A first row whose domain cell is a column name
+// such as "domain", "host" or "url" is treated as a header. Rows that are
+// too short or fail validation are kept and carry the reason in the error
+// column.
+func (c *Client) ValidateCSV(ctx context.Context, in io.Reader, out io.Writer, domainColumn int) error {
+	if domainColumn < 0 {
+		return fmt.Errorf("invalid domain column: %d", domainColumn)
+	}
+
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	writer := csv.NewWriter(out)
+
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return err
+			}
+			if err := writer.Write(append(record, "", "", "", err.Error())); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if line == 0 && isCSVHeader(record, domainColumn) {
+			if err := writer.Write(append(record, csvColumns...)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if domainColumn >= len(record) {
+			if err := writer.Write(append(record, "", "", "", "missing domain column")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		info, err := c.ValidateDomain(ctx, record[domainColumn])
+		if err != nil {
+			record = append(record, "", "", "", err.Error())
+		} else {
+			var city, country string
+			if info.Location != nil {
+				city = info.Location.City
+				country = info.Location.Country
+			}
+			record = append(record, info.IPAddress, city, country, "")
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		writer.Flush()
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func isCSVHeader(record []string, domainColumn int) bool {
+	if domainColumn >= len(record) {
+		return false
+	}
+	return csvHeaderNames[strings.ToLower(strings.TrimSpace(record[domainColumn]))]
+}
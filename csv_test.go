@@ -0,0 +1,23 @@
+package domaininfo
+
+import "testing"
+
+func TestIsCSVHeader(t *testing.T) {
+	tests := []struct {
+		record []string
+		want   bool
+	}{
+		{record: []string{"id", "Domain"}, want: true},
+		{record: []string{"id", " hostname "}, want: true},
+		{record: []string{"1", "example.com"}, want: false},
+		{record: []string{"1", "not a domain"}, want: false},
+		{record: []string{"1", "-bad-.com"}, want: false},
+		{record: []string{"domain"}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isCSVHeader(tt.record, 1); got != tt.want {
+			t.Errorf("isCSVHeader(%q) = %v, want %v", tt.record, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package domaininfo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// dialContext dials address, binding to Client.LocalAddr when set.
+func (c *Client) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := net.Dialer{LocalAddr: localAddrFor(c.LocalAddr, network)}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// localAddrFor converts addr to the address type network expects, since a
+// net.Dialer rejects a TCP local address for a UDP dial and vice versa.
+func localAddrFor(addr net.Addr, network string) net.Addr {
+	var ip net.IP
+	var zone string
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ip, zone = a.IP, a.Zone
+	case *net.UDPAddr:
+		ip, zone = a.IP, a.Zone
+	case *net.IPAddr:
+		ip, zone = a.IP, a.Zone
+	default:
+		return nil
+	}
+
+	if strings.HasPrefix(network, "udp") {
+		return &net.UDPAddr{IP: ip, Zone: zone}
+	}
+	return &net.TCPAddr{IP: ip, Zone: zone}
+}
+
+func (c *Client) boundHTTPClient() *http.Client {
+	c.boundHTTPOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = c.dialContext
+		c.boundHTTP = &http.Client{Transport: transport}
+	})
+	return c.boundHTTP
+}
+
+func (c *Client) boundResolver() *net.Resolver {
+	return &net.Resolver{PreferGo: true, Dial: c.dialContext}
+}
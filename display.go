@@ -0,0 +1,30 @@
+package domaininfo
+
+// DisplayName returns the domain in Unicode form for user-facing output, so
+// internationalized names do not show up as xn-- labels.
+func (d *DomainInfo) DisplayName() string {
+	if d.UnicodeDomain != "" {
+		return d.UnicodeDomain
+	}
+	return ToUnicode(d.CleanDomain)
+}
+
+// LogFields returns slog-compatible key/value pairs describing the result:
+// domain and ip, plus country and asn when a location is known.
+//
+//	slog.Info("validated", info.LogFields()...)
+func (d *DomainInfo) LogFields() []any {
+	if d == nil {
+		return nil
+	}
+
+	fields := []any{"domain", d.CleanDomain, "ip", d.IPAddress}
+	if d.Location != nil {
+		country := d.Location.CountryCode
+		if country == "" {
+			country = d.Location.Country
+		}
+		fields = append(fields, "country", country, "asn", d.Location.ASN)
+	}
+	return fields
+}
@@ -0,0 +1,135 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// DNSSnapshot holds the records returned by LookupAll. Errors is keyed by
+// record type ("A", "MX", "NS", "TXT", "CNAME") and only contains the
+// lookups that failed.
+type DNSSnapshot struct {
+	Domain string
+	A      []string
+	AAAA   []string
+	MX     []*net.MX
+	NS     []string
+	TXT    []string
+	CNAME  string
+	Errors map[string]error
+
+	// DNSProvider names the DNS host behind NS when it supports apex
+	// aliases. ApexAlias is set when the apex appears to resolve through
+	// such a flattened alias; it is a heuristic.
+	DNSProvider string
+	ApexAlias   bool
+}
+
+func LookupAll(ctx context.Context, domain string) (*DNSSnapshot, error) {
+	return defaultClient.LookupAll(ctx, domain)
+}
+
+// LookupAll queries A/AAAA, MX, NS, TXT and CNAME records concurrently. A
+// failing record type is reported in DNSSnapshot.Errors; an error is only
+// returned when every lookup failed, or when domain fails the same checks
+// as in Resolve. domain is normalized like Resolve normalizes it.
+func (c *Client) LookupAll(ctx context.Context, domain string) (*DNSSnapshot, error) {
+	domain = strings.ToLower(c.normalizeDomain(domain))
+	if err := c.checkDomain(domain); err != nil {
+		return nil, err
+	}
+	resolver := c.resolver()
+	snapshot := &DNSSnapshot{Domain: domain, Errors: make(map[string]error)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	lookup := func(recordType string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := fn()
+			if err != nil {
+				mu.Lock()
+				snapshot.Errors[recordType] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	lookup("A", func() error {
+		ips, _, err := c.lookupIP(ctx, domain)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ip := range ips {
+			if ip.To4() != nil {
+				snapshot.A = append(snapshot.A, ip.String())
+			} else {
+				snapshot.AAAA = append(snapshot.AAAA, ip.String())
+			}
+		}
+		return nil
+	})
+
+	lookup("MX", func() error {
+		mx, err := resolver.LookupMX(ctx, domain)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		snapshot.MX = mx
+		mu.Unlock()
+		return nil
+	})
+
+	lookup("NS", func() error {
+		ns, err := resolver.LookupNS(ctx, domain)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, n := range ns {
+			snapshot.NS = append(snapshot.NS, strings.TrimSuffix(n.Host, "."))
+		}
+		return nil
+	})
+
+	lookup("TXT", func() error {
+		txt, err := resolver.LookupTXT(ctx, domain)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		snapshot.TXT = txt
+		mu.Unlock()
+		return nil
+	})
+
+	lookup("CNAME", func() error {
+		cname, err := resolver.LookupCNAME(ctx, domain)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		snapshot.CNAME = strings.TrimSuffix(cname, ".")
+		mu.Unlock()
+		return nil
+	})
+
+	wg.Wait()
+
+	if len(snapshot.Errors) == 5 {
+		return snapshot, fmt.Errorf("all DNS lookups failed for %s", domain)
+	}
+
+	snapshot.DNSProvider = aliasDNSProvider(snapshot.NS)
+	snapshot.ApexAlias = isApexAlias(snapshot)
+
+	return snapshot, nil
+}
@@ -0,0 +1,27 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestLookupAllNormalizesInput(t *testing.T) {
+	client := &Client{Resolver: fakeDNS(t, net.ParseIP("192.0.2.8"))}
+
+	snapshot, err := client.LookupAll(context.Background(), "HTTPS://Shop.Bücher.Example/path")
+	if err != nil {
+		t.Fatalf("LookupAll: %v", err)
+	}
+	if snapshot.Domain != "shop.xn--bcher-kva.example" {
+		t.Errorf("Domain = %q, want shop.xn--bcher-kva.example", snapshot.Domain)
+	}
+	if len(snapshot.A) != 1 || snapshot.A[0] != "192.0.2.8" {
+		t.Errorf("A = %v, want 192.0.2.8", snapshot.A)
+	}
+
+	if _, err := client.LookupAll(context.Background(), "not a domain"); !errors.Is(err, ErrInvalidDomainFormat) {
+		t.Errorf("LookupAll(invalid) error = %v, want ErrInvalidDomainFormat", err)
+	}
+}
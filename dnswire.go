@@ -0,0 +1,319 @@
+package domaininfo
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// A minimal DNS client for the few lookups the net package cannot express,
+// such as reading CNAME chains, response codes or non-recursive answers.
+
+const (
+	dnsTypeCNAME = 5
+	dnsTypeNS    = 2
+	dnsTypePTR   = 12
+	dnsClassIN   = 1
+)
+
+var dnsRcodeNames = map[int]string{
+	0:  "NOERROR",
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	6:  "YXDOMAIN",
+	7:  "YXRRSET",
+	8:  "NXRRSET",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+}
+
+type dnsRecord struct {
+	Name string
+	Type uint16
+	TTL  uint32
+	Data string
+}
+
+type dnsResponse struct {
+	RCode         int
+	Authoritative bool
+	Truncated     bool
+	Answers       []dnsRecord
+	Authority     []dnsRecord
+	Additional    []dnsRecord
+}
+
+func rcodeName(rcode int) string {
+	if name, ok := dnsRcodeNames[rcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// dnsServer returns the server used for wire-level queries: the first of
+// Client.Resolvers, else the first nameserver in /etc/resolv.conf.
+func (c *Client) dnsServer() string {
+	if len(c.Resolvers) > 0 {
+		return resolverAddress(c.Resolvers[0])
+	}
+
+	f, err := os.Open("/etc/resolv.conf")
+	if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) >= 2 && fields[0] == "nameserver" {
+				return resolverAddress(fields[1])
+			}
+		}
+	}
+
+	return "127.0.0.1:53"
+}
+
+const defaultDNSTimeout = 5 * time.Second
+
+// exchange sends a single query for name and qtype to server over UDP,
+// retrying over TCP when the answer is truncated or the UDP exchange fails,
+// for example because the packet was lost.
+func (c *Client) exchange(ctx context.Context, server, name string, qtype uint16, recursion bool) (*dnsResponse, error) {
+	query, id, err := buildDNSQuery(name, qtype, recursion)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.exchangeOver(ctx, "udp", server, query, id)
+	if (err == nil && resp.Truncated) || (err != nil && ctx.Err() == nil) {
+		resp, err = c.exchangeOver(ctx, "tcp", server, query, id)
+	}
+	return resp, err
+}
+
+// dnsDeadline is when one exchange gives up: Client.DNSTimeout from now,
+// or the context deadline if that is sooner.
+func (c *Client) dnsDeadline(ctx context.Context) time.Time {
+	timeout := c.DNSTimeout
+	if timeout <= 0 {
+		timeout = defaultDNSTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	return deadline
+}
+
+func (c *Client) exchangeOver(ctx context.Context, network, server string, query []byte, id uint16) (*dnsResponse, error) {
+	conn, err := c.dialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(c.dnsDeadline(ctx))
+
+	var msg []byte
+	if network == "tcp" {
+		framed := make([]byte, 2+len(query))
+		binary.BigEndian.PutUint16(framed, uint16(len(query)))
+		copy(framed[2:], query)
+		if _, err := conn.Write(framed); err != nil {
+			return nil, err
+		}
+
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		msg = make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, msg); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		// Skip stray or spoofed packets with another ID until the
+		// deadline.
+		buf := make([]byte, 4096)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return nil, err
+			}
+			if n >= 2 && binary.BigEndian.Uint16(buf) == id {
+				msg = buf[:n]
+				break
+			}
+		}
+	}
+
+	if len(msg) < 2 || binary.BigEndian.Uint16(msg) != id {
+		return nil, errors.New("DNS response ID mismatch")
+	}
+	return parseDNSResponse(msg)
+}
+
+func buildDNSQuery(name string, qtype uint16, recursion bool) ([]byte, uint16, error) {
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, 0, err
+	}
+	id := binary.BigEndian.Uint16(idBytes[:])
+
+	var flags uint16
+	if recursion {
+		flags |= 1 << 8
+	}
+
+	msg := make([]byte, 12, 12+len(name)+6)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], flags)
+	binary.BigEndian.PutUint16(msg[4:], 1)
+
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, 0, fmt.Errorf("invalid DNS name %q", name)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0)
+	msg = binary.BigEndian.AppendUint16(msg, qtype)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+
+	return msg, id, nil
+}
+
+func parseDNSResponse(msg []byte) (*dnsResponse, error) {
+	if len(msg) < 12 {
+		return nil, errors.New("DNS response too short")
+	}
+
+	flags := binary.BigEndian.Uint16(msg[2:])
+	resp := &dnsResponse{
+		RCode:         int(flags & 0xf),
+		Authoritative: flags&(1<<10) != 0,
+		Truncated:     flags&(1<<9) != 0,
+	}
+
+	qdCount := int(binary.BigEndian.Uint16(msg[4:]))
+	counts := []int{
+		int(binary.BigEndian.Uint16(msg[6:])),
+		int(binary.BigEndian.Uint16(msg[8:])),
+		int(binary.BigEndian.Uint16(msg[10:])),
+	}
+
+	off := 12
+	for i := 0; i < qdCount; i++ {
+		_, next, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, err
+		}
+		off = next + 4
+	}
+
+	sections := []*[]dnsRecord{&resp.Answers, &resp.Authority, &resp.Additional}
+	for s, count := range counts {
+		for i := 0; i < count; i++ {
+			record, next, err := readDNSRecord(msg, off)
+			if err != nil {
+				if resp.Truncated {
+					return resp, nil
+				}
+				return nil, err
+			}
+			off = next
+			if record != nil {
+				*sections[s] = append(*sections[s], *record)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+func readDNSRecord(msg []byte, off int) (*dnsRecord, int, error) {
+	name, off, err := readDNSName(msg, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	if off+10 > len(msg) {
+		return nil, 0, errors.New("DNS record truncated")
+	}
+
+	rtype := binary.BigEndian.Uint16(msg[off:])
+	ttl := binary.BigEndian.Uint32(msg[off+4:])
+	length := int(binary.BigEndian.Uint16(msg[off+8:]))
+	off += 10
+	if off+length > len(msg) {
+		return nil, 0, errors.New("DNS record data truncated")
+	}
+	rdata := msg[off : off+length]
+	end := off + length
+
+	record := &dnsRecord{Name: name, Type: rtype, TTL: ttl}
+	switch rtype {
+	case dnsTypeA, dnsTypeAAAA:
+		record.Data = net.IP(rdata).String()
+	case dnsTypeCNAME, dnsTypeNS, dnsTypePTR:
+		target, _, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		record.Data = target
+	default:
+		record.Data = fmt.Sprintf("%x", rdata)
+	}
+	return record, end, nil
+}
+
+// readDNSName decodes a possibly compressed name at off and returns it in
+// lowercase without the trailing dot, along with the offset after it.
+func readDNSName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	next := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errors.New("DNS name truncated")
+		}
+
+		length := int(msg[off])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.ToLower(strings.Join(labels, ".")), next, nil
+		case length&0xc0 == 0xc0:
+			if off+1 >= len(msg) {
+				return "", 0, errors.New("DNS name truncated")
+			}
+			if jumps++; jumps > 32 {
+				return "", 0, errors.New("DNS name compression loop")
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+		default:
+			if off+1+length > len(msg) {
+				return "", 0, errors.New("DNS label truncated")
+			}
+			labels = append(labels, string(msg[off+1:off+1+length]))
+			off += 1 + length
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package domaininfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	CloudflareDoH = "https://cloudflare-dns.com/dns-query"
+	GoogleDoH     = "https://dns.google/resolve"
+)
+
+const (
+	dnsTypeA    = 1
+	dnsTypeMX   = 15
+	dnsTypeAAAA = 28
+
+	dohStatusNXDomain = 3
+)
+
+type dohResponse struct {
+	Status int `json:"Status"`
+	Answer []struct {
+		Name string `json:"name"`
+		Type int    `json:"type"`
+		Data string `json:"data"`
+	} `json:"Answer"`
+}
+
+func (c *Client) lookupIPDoH(ctx context.Context, domain string) ([]net.IP, error) {
+	var (
+		wg      sync.WaitGroup
+		results [2][]net.IP
+		errs    [2]error
+	)
+	for i, qtype := range []int{dnsTypeA, dnsTypeAAAA} {
+		wg.Add(1)
+		go func(i, qtype int) {
+			defer wg.Done()
+			results[i], errs[i] = c.queryDoH(ctx, domain, qtype)
+		}(i, qtype)
+	}
+	wg.Wait()
+
+	ips := append(results[0], results[1]...)
+	if len(ips) > 0 {
+		return ips, nil
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: domain, Server: c.DoHURL, IsNotFound: true}
+}
+
+func (c *Client) queryDoH(ctx context.Context, domain string, qtype int) ([]net.IP, error) {
+	records, err := c.queryDoHRecords(ctx, domain, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	for _, record := range records {
+		if ip := net.ParseIP(record); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+// lookupMXDoH resolves the MX records of domain through DoHURL.
+func (c *Client) lookupMXDoH(ctx context.Context, domain string) ([]*net.MX, error) {
+	records, err := c.queryDoHRecords(ctx, domain, dnsTypeMX)
+	if err != nil {
+		return nil, err
+	}
+
+	var mx []*net.MX
+	for _, record := range records {
+		var pref uint16
+		var host string
+		if _, err := fmt.Sscan(record, &pref, &host); err == nil {
+			mx = append(mx, &net.MX{Host: host, Pref: pref})
+		}
+	}
+	if len(mx) == 0 {
+		return nil, &net.DNSError{Err: "no MX records", Name: domain, Server: c.DoHURL, IsNotFound: true}
+	}
+	return mx, nil
+}
+
+// queryDoHRecords returns the data of every answer of type qtype for
+// domain from DoHURL.
+func (c *Client) queryDoHRecords(ctx context.Context, domain string, qtype int) ([]string, error) {
+	query := url.Values{"name": {domain}, "type": {fmt.Sprint(qtype)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.DoHURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: domain, Server: c.DoHURL, IsTimeout: isTimeout(err), IsTemporary: true}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &net.DNSError{Err: "unexpected status: " + resp.Status, Name: domain, Server: c.DoHURL, IsTemporary: resp.StatusCode >= 500}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer dohResponse
+	if err := json.Unmarshal(body, &answer); err != nil {
+		return nil, err
+	}
+
+	switch answer.Status {
+	case 0:
+	case dohStatusNXDomain:
+		return nil, &net.DNSError{Err: "no such host", Name: domain, Server: c.DoHURL, IsNotFound: true}
+	default:
+		return nil, &net.DNSError{Err: fmt.Sprintf("server returned rcode %d", answer.Status), Name: domain, Server: c.DoHURL, IsTemporary: true}
+	}
+
+	var records []string
+	for _, record := range answer.Answer {
+		if record.Type == qtype {
+			records = append(records, record.Data)
+		}
+	}
+	return records, nil
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,66 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func ValidateEmailDomain(email string) (*DomainInfo, error) {
+	return defaultClient.ValidateEmailDomain(context.Background(), email)
+}
+
+// ValidateEmailDomain checks that the domain part of email can receive
+// mail. The domain is converted to punycode, validated like Resolve
+// validates domains, and must publish MX records; ErrNoMXRecords is
+// returned otherwise. MX records are looked up through HostOverrides, DoH
+// or Resolvers like address records. The result carries the MX hosts in
+// priority order and, when the domain also has an address record, its IP.
+// No geolocation is performed.
+func (c *Client) ValidateEmailDomain(ctx context.Context, email string) (*DomainInfo, error) {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return nil, ErrInvalidEmail
+	}
+
+	domain := ToASCII(strings.TrimSuffix(strings.TrimSpace(email[at+1:]), "."))
+	if err := c.checkDomain(domain); err != nil {
+		return nil, err
+	}
+
+	mx, _, err := c.lookupMX(ctx, domain)
+	if err != nil {
+		if classified := classifyDNSError(err); IsRetryable(classified) {
+			return nil, classified
+		}
+		return nil, fmt.Errorf("%w: %v", ErrNoMXRecords, err)
+	}
+
+	sort.SliceStable(mx, func(i, j int) bool { return mx[i].Pref < mx[j].Pref })
+	var hosts []string
+	for _, record := range mx {
+		host := strings.TrimSuffix(record.Host, ".")
+		if host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	// A single "." MX is a null MX (RFC 7505): the domain accepts no mail.
+	if len(hosts) == 0 {
+		return nil, ErrNoMXRecords
+	}
+
+	info := &DomainInfo{
+		OriginalInput: email,
+		CleanDomain:   domain,
+		UnicodeDomain: ToUnicode(domain),
+		MXHosts:       hosts,
+	}
+	if ips, resolvedBy, err := c.getIPAddress(ctx, domain); err == nil && len(ips) > 0 {
+		info.IPAddress = ips[0]
+		info.IPAddresses = ips
+		info.ResolvedBy = resolvedBy
+		info.ResolutionMethod = c.resolutionMethod(resolvedBy)
+	}
+	return info, nil
+}
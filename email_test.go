@@ -0,0 +1,66 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateEmailDomainRejectsInvalidDomains(t *testing.T) {
+	tests := []struct {
+		email string
+		want  error
+	}{
+		{email: "user", want: ErrInvalidEmail},
+		{email: "user@", want: ErrInvalidEmail},
+		{email: "user@-bad-.com", want: ErrInvalidDomainFormat},
+		{email: "user@localhost", want: ErrInvalidDomainFormat},
+		{email: "user@192.0.2.1", want: ErrInputIsIP},
+		{email: "user@" + strings.Repeat("a", 64) + ".com", want: ErrLabelTooLong},
+	}
+
+	client := &Client{}
+	for _, tt := range tests {
+		if _, err := client.ValidateEmailDomain(context.Background(), tt.email); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateEmailDomain(%q) error = %v, want %v", tt.email, err, tt.want)
+		}
+	}
+}
+
+func TestValidateEmailDomainUsesDoH(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("type") {
+		case "15":
+			w.Write([]byte(`{"Status":0,"Answer":[{"type":15,"data":"20 mx2.xn--bcher-kva.example."},{"type":15,"data":"10 mx1.xn--bcher-kva.example."}]}`))
+		default:
+			w.Write([]byte(`{"Status":0,"Answer":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{DoHURL: server.URL, HTTPClient: server.Client()}
+	info, err := client.ValidateEmailDomain(context.Background(), "user@bücher.example")
+	if err != nil {
+		t.Fatalf("ValidateEmailDomain: %v", err)
+	}
+	if len(info.MXHosts) != 2 || info.MXHosts[0] != "mx1.xn--bcher-kva.example" {
+		t.Errorf("MXHosts = %v, want mx1 first", info.MXHosts)
+	}
+	if info.UnicodeDomain != "bücher.example" {
+		t.Errorf("UnicodeDomain = %q, want bücher.example", info.UnicodeDomain)
+	}
+}
+
+func TestValidateEmailDomainHostOverride(t *testing.T) {
+	client := &Client{HostOverrides: map[string]string{"example.com": "192.0.2.1"}}
+	info, err := client.ValidateEmailDomain(context.Background(), "user@example.com")
+	if err != nil {
+		t.Fatalf("ValidateEmailDomain: %v", err)
+	}
+	if len(info.MXHosts) != 1 || info.MXHosts[0] != "example.com" || info.IPAddress != "192.0.2.1" {
+		t.Errorf("ValidateEmailDomain = %v at %s, want example.com at 192.0.2.1", info.MXHosts, info.IPAddress)
+	}
+}
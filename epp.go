@@ -0,0 +1,82 @@
+package domaininfo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// eppStatuses lists the EPP status codes from RFC 5731 and RFC 3915 in
+// their canonical spelling, keyed by their lowercase letters.
+var eppStatuses = map[string]string{}
+
+func init() {
+	for _, code := range []string{
+		"ok", "inactive", "active",
+		"addPeriod", "autoRenewPeriod", "renewPeriod", "transferPeriod",
+		"redemptionPeriod", "pendingRestore",
+		"pendingCreate", "pendingDelete", "pendingRenew", "pendingTransfer", "pendingUpdate",
+		"clientDeleteProhibited", "clientHold", "clientRenewProhibited",
+		"clientTransferProhibited", "clientUpdateProhibited",
+		"serverDeleteProhibited", "serverHold", "serverRenewProhibited",
+		"serverTransferProhibited", "serverUpdateProhibited",
+	} {
+		eppStatuses[strings.ToLower(code)] = code
+	}
+}
+
+// normalizeEPPStatus maps the spellings registrars use, such as
+// "client transfer prohibited", "CLIENT-TRANSFER-PROHIBITED" or
+// "clientTransferProhibited https://icann.org/epp#...", to the canonical
+// EPP code. Unknown statuses are returned lowercased.
+func normalizeEPPStatus(status string) string {
+	status = strings.TrimSpace(status)
+	if i := strings.Index(status, "http"); i > 0 {
+		status = status[:i]
+	}
+	if i := strings.IndexByte(status, '('); i > 0 {
+		status = status[:i]
+	}
+
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, status)
+
+	if code, ok := eppStatuses[key]; ok {
+		return code
+	}
+	return strings.ToLower(strings.TrimSpace(status))
+}
+
+func appendStatus(statuses []string, raw string) []string {
+	status := normalizeEPPStatus(raw)
+	if status == "" {
+		return statuses
+	}
+	for _, existing := range statuses {
+		if existing == status {
+			return statuses
+		}
+	}
+	return append(statuses, status)
+}
+
+func hasTransferLock(statuses []string) bool {
+	for _, status := range statuses {
+		if status == "clientTransferProhibited" || status == "serverTransferProhibited" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPendingDeletion(statuses []string) bool {
+	for _, status := range statuses {
+		if status == "pendingDelete" || status == "redemptionPeriod" {
+			return true
+		}
+	}
+	return false
+}
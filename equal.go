@@ -0,0 +1,22 @@
+package domaininfo
+
+import "strings"
+
+// DomainsEqual reports whether a and b name the same domain once both are
+// cleaned and normalized: scheme, path and www prefix removed, lowercased,
+// trailing dot stripped and internationalized names converted to punycode.
+// "HTTP://WWW.Example.COM./" and "example.com" are equal.
+func DomainsEqual(a, b string) bool {
+	na, nb := canonicalEqualForm(a), canonicalEqualForm(b)
+	return na != "" && na == nb
+}
+
+// canonicalEqualForm is the form DomainsEqual compares. Unlike
+// Client.normalizeDomain, it runs no CleanHooks.
+func canonicalEqualForm(input string) string {
+	domain := cleanDomainInput(strings.ToLower(strings.TrimSpace(input)))
+	if i := strings.IndexAny(domain, "/?#"); i >= 0 {
+		domain = domain[:i]
+	}
+	return ToASCII(canonicalizeDomain(domain))
+}
@@ -0,0 +1,118 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+var (
+	ErrInvalidDomainFormat  = errors.New("invalid domain format")
+	ErrDomainTooLong        = errors.New("domain exceeds 253 characters")
+	ErrLabelTooLong         = errors.New("domain label exceeds 63 characters")
+	ErrDomainNotFound       = errors.New("domain not found")
+	ErrDNSTimeout           = errors.New("DNS lookup timed out")
+	ErrTimeout              = errors.New("domain validation timed out")
+	ErrTLDNotAllowed        = errors.New("TLD not allowed")
+	ErrInputIsIP            = errors.New("input is an IP address, not a domain")
+	ErrCNAMEChainTooLong    = errors.New("CNAME chain exceeds maximum depth")
+	ErrInvalidEmail         = errors.New("invalid email address")
+	ErrNoMXRecords          = errors.New("domain has no MX records")
+	ErrInvalidIP            = errors.New("invalid IP address")
+	ErrEmptyResponse        = errors.New("empty response body")
+	ErrPassiveDNSDisabled   = errors.New("passive DNS endpoint not configured")
+	ErrInvalidPort          = errors.New("port must be between 1 and 65535")
+	ErrNoAbuseContact       = errors.New("no abuse contact found")
+	ErrOutsideExpectedRange = errors.New("resolved IP outside expected ranges")
+	ErrNoCreationDate       = errors.New("domain creation date unavailable")
+	ErrReverseIPDisabled    = errors.New("reverse IP endpoint or API key not configured")
+	ErrNotInFixture         = errors.New("domain not in fixture file")
+	ErrNoCoordinates        = errors.New("location has no coordinates")
+	ErrRedacted             = errors.New("registrant data redacted for privacy")
+)
+
+// StatusError is returned when an HTTP endpoint answers with a status other
+// than 200 OK.
+type StatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func providersFailed(lastErr error) error {
+	if lastErr == nil {
+		return fmt.Errorf("could not fetch location from any provider")
+	}
+	return fmt.Errorf("could not fetch location from any provider: %w", lastErr)
+}
+
+var permanentErrors = []error{
+	ErrInvalidDomainFormat,
+	ErrDomainTooLong,
+	ErrLabelTooLong,
+	ErrDomainNotFound,
+	ErrTLDNotAllowed,
+	ErrInputIsIP,
+	ErrCNAMEChainTooLong,
+	ErrInvalidEmail,
+	ErrNoMXRecords,
+	ErrInvalidIP,
+	ErrPassiveDNSDisabled,
+	ErrReverseIPDisabled,
+	ErrNotInFixture,
+	ErrNoCoordinates,
+	ErrRedacted,
+	ErrInvalidPort,
+	ErrNoAbuseContact,
+	ErrOutsideExpectedRange,
+	context.Canceled,
+}
+
+// IsRetryable reports whether err is likely transient: timeouts, HTTP 429
+// and 5xx responses, empty response bodies, and temporary network failures
+// such as connection resets. Invalid input, NXDOMAIN and policy
+// rejections are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, permanent := range permanentErrors {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+
+	if errors.Is(err, ErrDNSTimeout) || errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, ErrEmptyResponse) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,94 @@
+package domaininfo
+
+import (
+	"context"
+	"time"
+)
+
+// Thresholds for DetectFastFlux. Legitimate round-robin and CDN setups
+// rotate through a handful of addresses in one or two networks; fast-flux
+// botnets spread over many unrelated ones.
+const (
+	fastFluxMinIPs  = 5
+	fastFluxMinASNs = 3
+)
+
+func DetectFastFlux(domain string, samples int, interval time.Duration) (bool, []string, error) {
+	return defaultClient.DetectFastFlux(context.Background(), domain, samples, interval)
+}
+
+// DetectFastFlux resolves domain samples times, interval apart, and reports
+// whether its address set kept changing across many distinct IPs and ASNs,
+// a fast-flux indicator. The distinct IPs observed are returned in the
+// order first seen. Every distinct IP is geolocated to find its ASN, which
+// counts against provider quotas.
+func (c *Client) DetectFastFlux(ctx context.Context, domain string, samples int, interval time.Duration) (bool, []string, error) {
+	domain = ToASCII(cleanDomainInput(domain))
+	samples = max(samples, 2)
+
+	seen := make(map[string]bool)
+	var ips []string
+	var previous map[string]bool
+	changes := 0
+
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return false, ips, ctx.Err()
+			}
+		}
+
+		resolved, _, err := c.lookupIP(ctx, domain)
+		if err != nil {
+			return false, ips, classifyDNSError(err)
+		}
+
+		current := make(map[string]bool, len(resolved))
+		for _, ip := range resolved {
+			addr := ip.String()
+			current[addr] = true
+			if !seen[addr] {
+				seen[addr] = true
+				ips = append(ips, addr)
+			}
+		}
+		if previous != nil && !sameSet(previous, current) {
+			changes++
+		}
+		previous = current
+	}
+
+	if changes == 0 || len(ips) < fastFluxMinIPs {
+		return false, ips, nil
+	}
+
+	asns := make(map[string]bool)
+	for _, ip := range ips {
+		location, err := c.getIPLocation(ctx, ip, "")
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, ips, ctx.Err()
+			}
+			continue
+		}
+		if location.ASN != "" {
+			asns[location.ASN] = true
+		}
+	}
+
+	return len(asns) >= fastFluxMinASNs, ips, nil
+}
+
+func sameSet(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if !b[key] {
+			return false
+		}
+	}
+	return true
+}
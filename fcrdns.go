@@ -0,0 +1,51 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+func CheckFCrDNS(ip string) (bool, error) {
+	return defaultClient.CheckFCrDNS(context.Background(), ip)
+}
+
+// CheckFCrDNS performs forward-confirmed reverse DNS: it looks up the PTR
+// names of ip and reports whether any of them resolves back to ip. An IP
+// without PTR records reports false without an error.
+func (c *Client) CheckFCrDNS(ctx context.Context, ip string) (bool, error) {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	if addr == nil {
+		return false, ErrInvalidIP
+	}
+
+	names, err := c.resolver().LookupAddr(ctx, addr.String())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, classifyDNSError(err)
+	}
+
+	var lastErr error
+	for _, name := range names {
+		forward, _, err := c.lookupIP(ctx, strings.TrimSuffix(name, "."))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, candidate := range forward {
+			if candidate.Equal(addr) {
+				return true, nil
+			}
+		}
+	}
+
+	// A forward lookup that failed transiently leaves the answer unknown.
+	if lastErr != nil && isTransientDNSError(lastErr) {
+		return false, classifyDNSError(lastErr)
+	}
+	return false, nil
+}
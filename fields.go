@@ -0,0 +1,112 @@
+package domaininfo
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldNames returns the JSON names of the LocationDetails fields in
+// declaration order, for building schemas or API documentation.
+func (LocationDetails) FieldNames() []string {
+	t := reflect.TypeOf(LocationDetails{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// ToMap flattens the location into string values keyed by the names
+// returned by FieldNames, plus the computed "eu" key, for templating and
+// CSV output. Numbers use the shortest decimal form that round-trips,
+// booleans are "true" or "false", and warnings are joined with "; ". Empty
+// and zero values are left out unless includeEmpty is set.
+func (l *LocationDetails) ToMap(includeEmpty bool) map[string]string {
+	v := reflect.ValueOf(l).Elem()
+	t := v.Type()
+
+	m := make(map[string]string, t.NumField()+1)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value := v.Field(i)
+		if value.IsZero() && !includeEmpty {
+			continue
+		}
+		m[name] = formatField(value)
+	}
+
+	if eu := l.IsEU(); eu || includeEmpty {
+		m["eu"] = strconv.FormatBool(eu)
+	}
+	return m
+}
+
+func formatField(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatField(v.Index(i))
+		}
+		return strings.Join(parts, "; ")
+	}
+	return ""
+}
+
+// missingFields returns the entries of required, given as Go or JSON field
+// names, that are unknown or hold their zero value in location.
+func missingFields(location *LocationDetails, required []string) []string {
+	v := reflect.ValueOf(location).Elem()
+
+	var missing []string
+	for _, want := range required {
+		i := locationFieldIndex(want)
+		if i < 0 || v.Field(i).IsZero() {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+// locationFieldIndex returns the index of the exported LocationDetails
+// field with the given Go or JSON name, or -1 if there is none.
+func locationFieldIndex(want string) int {
+	t := reflect.TypeOf(LocationDetails{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.IsExported() && (strings.EqualFold(field.Name, want) || name == want) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,40 @@
+package domaininfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// Fingerprint returns a hex SHA-256 digest of the fields that describe a
+// domain's infrastructure: CleanDomain, the sorted IPAddresses (or
+// IPAddress when IPAddresses is empty), and the Location's CountryCode (or
+// Country) and ASN. Everything else, such as the original input or timing
+// data, is excluded, so two scans of an unchanged domain yield the same
+// fingerprint.
+func (d *DomainInfo) Fingerprint() string {
+	ips := append([]string(nil), d.IPAddresses...)
+	if len(ips) == 0 && d.IPAddress != "" {
+		ips = []string{d.IPAddress}
+	}
+	sort.Strings(ips)
+
+	var country, asn string
+	if d.Location != nil {
+		country = d.Location.CountryCode
+		if country == "" {
+			country = d.Location.Country
+		}
+		asn = d.Location.ASN
+	}
+
+	var canonical strings.Builder
+	canonical.WriteString("domain=" + strings.ToLower(d.CleanDomain) + "\n")
+	canonical.WriteString("ips=" + strings.Join(ips, ",") + "\n")
+	canonical.WriteString("country=" + strings.ToUpper(country) + "\n")
+	canonical.WriteString("asn=" + strings.ToUpper(asn) + "\n")
+
+	sum := sha256.Sum256([]byte(canonical.String()))
+	return hex.EncodeToString(sum[:])
+}
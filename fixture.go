@@ -0,0 +1,85 @@
+package domaininfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const fixtureResolver = "fixture"
+
+// fixtures holds the DomainInfo records loaded from Client.FixtureFile.
+type fixtures struct {
+	once    sync.Once
+	entries map[string]*DomainInfo
+	err     error
+}
+
+// fixtureLookup returns the fixture recorded for domain. The file is read
+// on first use; a read or parse error is returned on every lookup.
+func (c *Client) fixtureLookup(domain string) (*DomainInfo, bool, error) {
+	c.fixtures.once.Do(func() {
+		data, err := os.ReadFile(c.FixtureFile)
+		if err != nil {
+			c.fixtures.err = fmt.Errorf("reading fixture file: %v", err)
+			return
+		}
+
+		var raw map[string]*DomainInfo
+		if err := json.Unmarshal(data, &raw); err != nil {
+			c.fixtures.err = fmt.Errorf("parsing fixture file %s: %v", c.FixtureFile, err)
+			return
+		}
+
+		c.fixtures.entries = make(map[string]*DomainInfo, len(raw))
+		for domain, info := range raw {
+			if info != nil {
+				c.fixtures.entries[canonicalizeDomain(ToASCII(domain))] = info
+			}
+		}
+	})
+	if c.fixtures.err != nil {
+		return nil, false, c.fixtures.err
+	}
+
+	fixture, ok := c.fixtures.entries[canonicalizeDomain(domain)]
+	return fixture, ok, nil
+}
+
+// resolveFixture answers Resolve from the fixture file. It reports false
+// when the domain is missing and FixtureFallthrough allows a live lookup.
+func (c *Client) resolveFixture(input, domain string) (*DomainInfo, bool, error) {
+	fixture, ok, err := c.fixtureLookup(domain)
+	if err != nil {
+		return nil, true, err
+	}
+	if !ok {
+		if c.FixtureFallthrough {
+			return nil, false, nil
+		}
+		return nil, true, fmt.Errorf("%w: %s", ErrNotInFixture, domain)
+	}
+
+	info := *fixture
+	info.OriginalInput = input
+	info.CleanDomain = domain
+	if info.UnicodeDomain == "" {
+		info.UnicodeDomain = ToUnicode(domain)
+	}
+	info.IPAddresses = append([]string(nil), fixture.IPAddresses...)
+	if info.IPAddress == "" && len(info.IPAddresses) > 0 {
+		info.IPAddress = info.IPAddresses[0]
+	}
+	if info.IPAddress != "" && len(info.IPAddresses) == 0 {
+		info.IPAddresses = []string{info.IPAddress}
+	}
+	if fixture.Location != nil {
+		location := *fixture.Location
+		info.Location = &location
+	}
+	info.WasWWW = isWWWAlias(input)
+	info.ResolvedBy = fixtureResolver
+	info.ResolutionMethod = fixtureResolver
+	return &info, true, nil
+}
@@ -0,0 +1,71 @@
+package domaininfo
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent geolocation lookups for the same key
+// so that only one provider request is in flight per IP. It is a minimal
+// version of golang.org/x/sync/singleflight that keeps the package free of
+// external dependencies.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done     chan struct{}
+	cancel   context.CancelFunc
+	waiters  int
+	location *LocationDetails
+	err      error
+}
+
+// do runs fn once per key among concurrent callers. fn runs in its own
+// goroutine under a context detached from the callers', so one caller
+// giving up does not fail the others. It is cancelled only once every
+// caller has stopped waiting.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (*LocationDetails, error)) (*LocationDetails, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			defer cancel()
+			call.location, call.err = fn(fctx)
+			g.mu.Lock()
+			g.forget(key, call)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.location, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		if call.waiters--; call.waiters == 0 {
+			call.cancel()
+			g.forget(key, call)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes call from the group unless a newer call has replaced it.
+// A cancelled call is forgotten at once, so later callers start afresh.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
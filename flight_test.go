@@ -0,0 +1,88 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n callers are waiting on key.
+func waitForWaiters(t *testing.T, g *flightGroup, key string, n int) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		call := g.calls[key]
+		waiting := call != nil && call.waiters == n
+		g.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d callers never waited on %s", n, key)
+}
+
+func TestFlightGroupSurvivesCallerCancel(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	fn := func(ctx context.Context) (*LocationDetails, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return &LocationDetails{City: "Berlin", Warnings: []string{"shared"}}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := g.do(first, "192.0.2.1", fn)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan *LocationDetails, 1)
+	go func() {
+		location, _ := g.do(context.Background(), "192.0.2.1", fn)
+		second <- location
+	}()
+	waitForWaiters(t, &g, "192.0.2.1", 2)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if location := <-second; location == nil || location.City != "Berlin" {
+		t.Fatalf("waiting caller got %+v, want Berlin", location)
+	}
+}
+
+func TestFlightGroupCancelsWhenAllCallersLeave(t *testing.T) {
+	var g flightGroup
+	cancelled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		g.do(ctx, "192.0.2.1", func(fctx context.Context) (*LocationDetails, error) {
+			<-fctx.Done()
+			close(cancelled)
+			return nil, fctx.Err()
+		})
+		close(done)
+	}()
+	waitForWaiters(t, &g, "192.0.2.1", 1)
+	cancel()
+	<-done
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared lookup was not cancelled after its only caller left")
+	}
+}
@@ -0,0 +1,22 @@
+package domaininfo
+
+import "context"
+
+type freshKey struct{}
+
+// WithFreshLookup returns a context that makes lookups under it skip the
+// geolocation and negative caches and repopulate them with the new answer.
+// The client's cache policy is otherwise unchanged, so this suits verifying
+// a record right after an infrastructure change.
+func WithFreshLookup(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshKey{}, true)
+}
+
+func isFreshLookup(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshKey{}).(bool)
+	return fresh
+}
+
+func ValidateDomainFresh(input string) (*DomainInfo, error) {
+	return defaultClient.ValidateDomain(WithFreshLookup(context.Background()), input)
+}
@@ -0,0 +1,66 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+func DetectGeoDNS(domain string, resolvers []string) (bool, map[string]string, error) {
+	return defaultClient.DetectGeoDNS(context.Background(), domain, resolvers)
+}
+
+// DetectGeoDNS resolves domain through each of resolvers ("host" or
+// "host:port") concurrently and reports whether their answers differ, which
+// indicates GeoDNS or split-horizon DNS when the resolvers sit in different
+// regions or networks. The returned map holds each answering resolver's
+// sorted, comma-separated addresses; resolvers that failed are left out,
+// and an error is only returned when all of them failed.
+func (c *Client) DetectGeoDNS(ctx context.Context, domain string, resolvers []string) (bool, map[string]string, error) {
+	domain = c.normalizeDomain(domain)
+	if err := c.checkDomain(domain); err != nil {
+		return false, nil, err
+	}
+
+	answers := make(map[string]string, len(resolvers))
+	errs := make([]error, len(resolvers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, server := range resolvers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			ips, err := c.resolverFor(resolverAddress(server)).LookupIP(ctx, "ip", domain)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", server, err)
+				return
+			}
+
+			addrs := make([]string, len(ips))
+			for j, ip := range ips {
+				addrs[j] = ip.String()
+			}
+			sort.Strings(addrs)
+			mu.Lock()
+			answers[server] = strings.Join(addrs, ",")
+			mu.Unlock()
+		}(i, server)
+	}
+	wg.Wait()
+
+	if len(answers) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return false, nil, classifyDNSError(err)
+		}
+		return false, answers, nil
+	}
+
+	distinct := make(map[string]bool)
+	for _, answer := range answers {
+		distinct[answer] = true
+	}
+	return len(distinct) > 1, answers, nil
+}
@@ -0,0 +1,80 @@
+package domaininfo
+
+import (
+	"encoding/json"
+	"math"
+)
+
+type geoJSONGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	Geometry   geoJSONGeometry   `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// GeoJSONCollection renders infos as a GeoJSON FeatureCollection with one
+// Point feature per domain, carrying domain, ip, country and asn
+// properties. Domains without a location, or whose coordinates are missing
+// or out of range, are skipped.
+func GeoJSONCollection(infos []*DomainInfo) ([]byte, error) {
+	collection := geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []geoJSONFeature{},
+	}
+
+	for _, info := range infos {
+		if feature, ok := geoJSONFeatureFor(info); ok {
+			collection.Features = append(collection.Features, feature)
+		}
+	}
+
+	return json.Marshal(collection)
+}
+
+func geoJSONFeatureFor(info *DomainInfo) (geoJSONFeature, bool) {
+	if info == nil || info.Location == nil || !validCoordinates(info.Location.Latitude, info.Location.Longitude) {
+		return geoJSONFeature{}, false
+	}
+	location := info.Location
+
+	properties := map[string]string{
+		"domain": info.CleanDomain,
+		"ip":     info.IPAddress,
+	}
+	if country := location.CountryCode; country != "" {
+		properties["country"] = country
+	} else if location.Country != "" {
+		properties["country"] = location.Country
+	}
+	if location.ASN != "" {
+		properties["asn"] = location.ASN
+	}
+
+	return geoJSONFeature{
+		Type: "Feature",
+		// GeoJSON positions are longitude first.
+		Geometry:   geoJSONGeometry{Type: "Point", Coordinates: [2]float64{location.Longitude, location.Latitude}},
+		Properties: properties,
+	}, true
+}
+
+// validCoordinates rejects NaN, out-of-range values and the 0,0 that
+// providers report when they have no coordinates.
+func validCoordinates(lat, long float64) bool {
+	if math.IsNaN(lat) || math.IsNaN(long) {
+		return false
+	}
+	if lat < -90 || lat > 90 || long < -180 || long > 180 {
+		return false
+	}
+	return lat != 0 || long != 0
+}
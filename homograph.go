@@ -0,0 +1,103 @@
+package domaininfo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// confusables maps characters that render like ASCII letters or digits to
+// that ASCII character. It covers the Cyrillic, Greek and Armenian letters
+// most commonly abused in lookalike domains; see Unicode TR39 for the full
+// data set.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i',
+	'ј': 'j', 'к': 'k', 'ӏ': 'l', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p',
+	'ԛ': 'q', 'ѕ': 's', 'т': 't', 'ц': 'u', 'ѵ': 'v', 'ԝ': 'w', 'х': 'x',
+	'у': 'y', 'ү': 'y', 'ѡ': 'w', 'ɡ': 'g', 'ь': 'b', 'г': 'r', 'п': 'n',
+	'ѳ': 'o', 'ӓ': 'a', 'ё': 'e', 'ї': 'i', 'ԍ': 'g', 'ԋ': 'h', 'з': '3',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v',
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'γ': 'y', 'ω': 'w',
+	'ϲ': 'c', 'ϳ': 'j', 'ϱ': 'p',
+	// Armenian
+	'ա': 'w', 'հ': 'h', 'ո': 'n', 'ս': 'u', 'օ': 'o', 'ց': 'g', 'զ': 'q',
+	// Latin lookalikes outside ASCII
+	'ı': 'i', 'ł': 'l', 'ø': 'o', 'đ': 'd', 'ħ': 'h', 'ŀ': 'l', 'ƅ': 'b',
+	'ǀ': 'l', 'ɑ': 'a', 'ɩ': 'i', 'ʀ': 'r', 'ѐ': 'e',
+	// Fullwidth and digit lookalikes
+	'０': '0', '１': '1', '２': '2', '３': '3', '４': '4', '５': '5',
+	'６': '6', '７': '7', '８': '8', '９': '9',
+}
+
+var homographScripts = []*unicode.RangeTable{
+	unicode.Latin, unicode.Cyrillic, unicode.Greek, unicode.Armenian,
+	unicode.Arabic, unicode.Hebrew, unicode.Han, unicode.Hiragana,
+	unicode.Katakana, unicode.Hangul, unicode.Thai, unicode.Devanagari,
+	unicode.Georgian, unicode.Cherokee,
+}
+
+// IsHomograph reports whether domain mixes scripts within a label, or has a
+// non-ASCII label made up entirely of characters confusable with ASCII, as
+// in "аpple.com" written with a Cyrillic "а". It also returns the skeleton
+// of the domain: its Unicode form with every confusable replaced by the
+// ASCII character it imitates. Both Unicode and punycode input are
+// accepted.
+func IsHomograph(domain string) (bool, string) {
+	unicodeDomain := strings.ToLower(ToUnicode(cleanDomainInput(domain)))
+
+	var suspicious bool
+	labels := strings.Split(unicodeDomain, ".")
+	for i, label := range labels {
+		if labelMixesScripts(label) {
+			suspicious = true
+		}
+
+		var skeleton strings.Builder
+		for _, r := range label {
+			if mapped, ok := confusables[r]; ok {
+				skeleton.WriteRune(mapped)
+			} else {
+				skeleton.WriteRune(unicode.ToLower(r))
+			}
+		}
+		labels[i] = skeleton.String()
+
+		if !isASCII(label) && isASCII(labels[i]) {
+			suspicious = true
+		}
+	}
+
+	return suspicious, strings.Join(labels, ".")
+}
+
+func labelMixesScripts(label string) bool {
+	var seen *unicode.RangeTable
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for _, script := range homographScripts {
+			if !unicode.Is(script, r) {
+				continue
+			}
+			if seen != nil && seen != script && !allowedScriptMix(seen, script) {
+				return true
+			}
+			if seen == nil {
+				seen = script
+			}
+			break
+		}
+	}
+	return false
+}
+
+func allowedScriptMix(a, b *unicode.RangeTable) bool {
+	japanese := map[*unicode.RangeTable]bool{unicode.Han: true, unicode.Hiragana: true, unicode.Katakana: true}
+	if japanese[a] && japanese[b] {
+		return true
+	}
+	korean := map[*unicode.RangeTable]bool{unicode.Han: true, unicode.Hangul: true}
+	return korean[a] && korean[b]
+}
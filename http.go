@@ -0,0 +1,52 @@
+package domaininfo
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+const maxHomepageBytes = 1 << 20
+
+type homepage struct {
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// fetchHomepage fetches the root page of domain over HTTPS, falling back to
+// HTTP. Internationalized names are converted to punycode first.
+func (c *Client) fetchHomepage(ctx context.Context, domain string) (*homepage, error) {
+	domain = ToASCII(domain)
+
+	var lastErr error
+	for _, scheme := range []string{"https://", "http://"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+domain+"/", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxHomepageBytes))
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return &homepage{
+			URL:        resp.Request.URL.String(),
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       body,
+		}, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,25 @@
+package domaininfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchHomepageConvertsIDN(t *testing.T) {
+	var host string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: &http.Client{Transport: rewriteTransport{server}}}
+	if _, err := client.fetchHomepage(context.Background(), "bücher.example"); err != nil {
+		t.Fatalf("fetchHomepage: %v", err)
+	}
+	if host != "xn--bcher-kva.example" {
+		t.Errorf("Host = %q, want xn--bcher-kva.example", host)
+	}
+}
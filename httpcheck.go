@@ -0,0 +1,173 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Histogram buckets used by CheckHTTPBatch for domains without a status.
+const (
+	HTTPBucketTimeout     = "timeout"
+	HTTPBucketUnreachable = "unreachable"
+	HTTPBucketCancelled   = "cancelled"
+)
+
+const defaultHTTPCheckTimeout = 10 * time.Second
+
+// HTTPStatus is the result of probing a domain over HTTP. Redirects are not
+// followed, so StatusCode is what the domain itself answered, with the
+// redirect target in Location. Err is set when no response was received.
+// Cancelled is set by CheckHTTPBatch for domains whose probe did not finish,
+// or never started, because the batch context ended.
+type HTTPStatus struct {
+	URL        string
+	StatusCode int
+	Location   string
+	Latency    time.Duration
+	TimedOut   bool
+	Cancelled  bool
+	Err        error
+}
+
+// Bucket returns the histogram bucket of s: the status code, or
+// HTTPBucketTimeout, HTTPBucketUnreachable or HTTPBucketCancelled.
+func (s *HTTPStatus) Bucket() string {
+	switch {
+	case s.Cancelled:
+		return HTTPBucketCancelled
+	case s.TimedOut:
+		return HTTPBucketTimeout
+	case s.Err != nil || s.StatusCode == 0:
+		return HTTPBucketUnreachable
+	}
+	return strconv.Itoa(s.StatusCode)
+}
+
+func CheckHTTP(domain string) *HTTPStatus {
+	return defaultClient.CheckHTTP(context.Background(), domain)
+}
+
+// CheckHTTP requests the root of domain over HTTPS, falling back to plain
+// HTTP when no HTTPS response is received, and reports the outcome.
+func (c *Client) CheckHTTP(ctx context.Context, domain string) *HTTPStatus {
+	domain = ToASCII(cleanDomainInput(domain))
+
+	client := *c.httpClient()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	var status *HTTPStatus
+	for _, scheme := range []string{"https://", "http://"} {
+		status = probeHTTP(ctx, &client, scheme+domain+"/")
+		if status.Err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	return status
+}
+
+func probeHTTP(ctx context.Context, client *http.Client, rawURL string) *HTTPStatus {
+	status := &HTTPStatus{URL: rawURL}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	status.Latency = time.Since(start)
+	if err != nil {
+		status.Err = err
+		status.TimedOut = isTimeout(err) || errors.Is(err, context.DeadlineExceeded)
+		return status
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxHomepageBytes))
+
+	status.StatusCode = resp.StatusCode
+	status.Location = resp.Header.Get("Location")
+	return status
+}
+
+func CheckHTTPBatch(ctx context.Context, domains []string, concurrency int) (map[string]*HTTPStatus, map[string]int) {
+	return defaultClient.CheckHTTPBatch(ctx, domains, concurrency)
+}
+
+// CheckHTTPBatch runs CheckHTTP over domains with up to concurrency
+// workers, or Client.Concurrency when concurrency is not positive, probing
+// duplicate inputs once. Each probe gets its own Client.DomainTimeout, or
+// 10 seconds when that is zero, so one slow host cannot hold a worker. It
+// returns each domain's status keyed by input, and a histogram counting
+// domains per Bucket: status codes such as "200" or "503", plus timeout,
+// unreachable and, for domains cut short by the end of ctx, cancelled.
+func (c *Client) CheckHTTPBatch(ctx context.Context, domains []string, concurrency int) (map[string]*HTTPStatus, map[string]int) {
+	if concurrency <= 0 {
+		concurrency = c.concurrency()
+	}
+
+	seen := make(map[string]bool, len(domains))
+	var unique []string
+	for _, domain := range domains {
+		if !seen[domain] {
+			seen[domain] = true
+			unique = append(unique, domain)
+		}
+	}
+
+	statuses := make(map[string]*HTTPStatus, len(unique))
+	histogram := make(map[string]int)
+	var mu sync.Mutex
+	jobs := make(chan string)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(unique)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for domain := range jobs {
+				status := c.checkHTTPWithin(ctx, domain)
+				mu.Lock()
+				statuses[domain] = status
+				histogram[status.Bucket()]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, domain := range unique {
+		jobs <- domain
+	}
+	close(jobs)
+	wg.Wait()
+
+	return statuses, histogram
+}
+
+// checkHTTPWithin runs CheckHTTP under the per-domain batch timeout. Only a
+// probe whose own deadline fired counts as timed out; one stopped by the end
+// of ctx is marked cancelled instead.
+func (c *Client) checkHTTPWithin(ctx context.Context, domain string) *HTTPStatus {
+	if err := ctx.Err(); err != nil {
+		return &HTTPStatus{Cancelled: true, Err: err}
+	}
+
+	timeout := c.DomainTimeout
+	if timeout <= 0 {
+		timeout = defaultHTTPCheckTimeout
+	}
+	dctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := c.CheckHTTP(dctx, domain)
+	if ctx.Err() != nil && status.Err != nil {
+		status.TimedOut, status.Cancelled = false, true
+	}
+	return status
+}
@@ -0,0 +1,48 @@
+package domaininfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckHTTPBatchPerDomainTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host == "slow.example.com" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HTTPClient:    &http.Client{Transport: rewriteTransport{server}},
+		DomainTimeout: 50 * time.Millisecond,
+	}
+	statuses, histogram := client.CheckHTTPBatch(context.Background(), []string{"slow.example.com", "fast.example.com"}, 1)
+	if !statuses["slow.example.com"].TimedOut {
+		t.Errorf("slow status = %+v, want timed out", statuses["slow.example.com"])
+	}
+	if statuses["fast.example.com"].StatusCode != http.StatusNoContent {
+		t.Errorf("fast status = %+v, want 204", statuses["fast.example.com"])
+	}
+	if histogram[HTTPBucketTimeout] != 1 || histogram["204"] != 1 {
+		t.Errorf("histogram = %v, want one timeout and one 204", histogram)
+	}
+}
+
+func TestCheckHTTPBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, histogram := (&Client{}).CheckHTTPBatch(ctx, []string{"a.example.com", "b.example.com"}, 2)
+	if histogram[HTTPBucketCancelled] != 2 || histogram[HTTPBucketTimeout] != 0 {
+		t.Errorf("histogram = %v, want both domains cancelled", histogram)
+	}
+}
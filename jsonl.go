@@ -0,0 +1,46 @@
+package domaininfo
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonlRecord struct {
+	Input         string      `json:"input"`
+	CleanDomain   string      `json:"clean_domain,omitempty"`
+	UnicodeDomain string      `json:"unicode_domain,omitempty"`
+	Domain        *DomainInfo `json:"domain,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// WriteJSONL writes one JSON object per line to w for every result received
+// on results, until the channel is closed. When w has a Flush method, such
+// as a bufio.Writer or http.Flusher, it is flushed after each line.
+func WriteJSONL(w io.Writer, results <-chan DomainResult) error {
+	encoder := json.NewEncoder(w)
+	for result := range results {
+		record := jsonlRecord{
+			Input:         result.Input,
+			CleanDomain:   result.CleanDomain,
+			UnicodeDomain: result.UnicodeDomain,
+			Domain:        result.Info,
+		}
+		if result.Err != nil {
+			record.Error = result.Err.Error()
+		}
+
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+
+		switch f := w.(type) {
+		case interface{ Flush() error }:
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		case interface{ Flush() }:
+			f.Flush()
+		}
+	}
+	return nil
+}
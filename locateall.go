@@ -0,0 +1,72 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+func LocateAll(domain string) ([]*DomainInfo, error) {
+	return defaultClient.LocateAll(context.Background(), domain)
+}
+
+// LocateAll resolves every A and AAAA record of domain and geolocates each
+// IP, returning one DomainInfo per IP in resolution order. Only the first
+// Client.MaxGeoIPs unique IPs are located when it is set. IPs that could
+// not be geolocated have a nil Location; an error is only returned when
+// resolution fails or no IP could be geolocated.
+func (c *Client) LocateAll(ctx context.Context, domain string) ([]*DomainInfo, error) {
+	resolved, err := c.Resolve(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := uniqueStrings(resolved.IPAddresses)
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("unable to resolve IP: no addresses for %s", resolved.CleanDomain)
+	}
+	if c.MaxGeoIPs > 0 && len(ips) > c.MaxGeoIPs {
+		ips = ips[:c.MaxGeoIPs]
+	}
+
+	infos := make([]*DomainInfo, len(ips))
+	errs := make([]error, len(ips))
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		info := *resolved
+		info.IPAddress = ip
+		if resolved.Timing != nil {
+			info.Timing = &Timing{DNS: resolved.Timing.DNS, IPLookup: resolved.Timing.IPLookup}
+		}
+		infos[i] = &info
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.Enrich(ctx, infos[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, info := range infos {
+		if info.Location != nil {
+			return infos, nil
+		}
+	}
+	return infos, fmt.Errorf("unable to fetch location for any IP of %s: %w", resolved.CleanDomain, errors.Join(errs...))
+}
+
+// uniqueStrings returns list without repeated entries, keeping the first
+// occurrence of each.
+func uniqueStrings(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	var unique []string
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,71 @@
+package domaininfo
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+)
+
+func LocateIPs(ctx context.Context, ips []string, concurrency int) (map[string]*LocationDetails, map[string]error) {
+	return defaultClient.LocateIPs(ctx, ips, concurrency)
+}
+
+// LocateIPs geolocates ips with up to concurrency workers, or
+// Client.Concurrency when concurrency is not positive. Duplicate IPs are
+// looked up once, and lookups go through the client's cache and rate
+// limit. Each distinct IP ends up in exactly one of the returned maps: its
+// location, or the error that prevented one.
+func (c *Client) LocateIPs(ctx context.Context, ips []string, concurrency int) (map[string]*LocationDetails, map[string]error) {
+	if concurrency <= 0 {
+		concurrency = c.concurrency()
+	}
+
+	seen := make(map[string]bool, len(ips))
+	var unique []string
+	for _, ip := range ips {
+		ip = strings.TrimSpace(ip)
+		if !seen[ip] {
+			seen[ip] = true
+			unique = append(unique, ip)
+		}
+	}
+
+	locations := make(map[string]*LocationDetails, len(unique))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	jobs := make(chan string)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(unique)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ip := range jobs {
+				var location *LocationDetails
+				var err error
+				if net.ParseIP(ip) == nil {
+					err = ErrInvalidIP
+				} else {
+					location, err = c.getIPLocation(ctx, ip, "")
+				}
+
+				mu.Lock()
+				if err != nil {
+					errs[ip] = err
+				} else {
+					locations[ip] = location
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, ip := range unique {
+		jobs <- ip
+	}
+	close(jobs)
+	wg.Wait()
+
+	return locations, errs
+}
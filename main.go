@@ -1,116 +1,574 @@
 package domaininfo
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net"
-	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 )
 
 type DomainInfo struct {
 	OriginalInput string
 	CleanDomain   string
 	IPAddress     string
+	IPAddresses   []string
 	Location      *LocationDetails
+
+	// WasWWW is set when the input was the www alias of CleanDomain.
+	WasWWW bool
+
+	// IsIPLiteral is set when the input's host was an IP address, such as
+	// "https://192.168.0.1:8080" or "[::1]", which is geolocated without
+	// DNS.
+	IsIPLiteral bool
+
+	// Port is the port given with the input, such as 8080 for
+	// "example.com:8080", when Client.AllowPort is set. It is zero otherwise.
+	Port int
+
+	// UnicodeDomain is CleanDomain with punycode labels decoded, for
+	// display. It equals CleanDomain for ASCII domains.
+	UnicodeDomain string
+
+	// ResolvedBy names the resolver that answered: "system", the address
+	// of one of Client.Resolvers, or the DoH endpoint. It is "override" for
+	// Client.HostOverrides, "supplied" for ValidateWithIP and "literal" for
+	// IP-literal input.
+	ResolvedBy string
+
+	// ResolutionMethod is the kind of path that produced the IPs: "system",
+	// "doh", "resolver" for Client.Resolvers, "override", "fixture",
+	// "supplied" or "literal".
+	ResolutionMethod string
+
+	// Timing is only set when Client.RecordTiming is enabled.
+	Timing *Timing
+
+	// MXHosts lists the mail exchangers in priority order. It is only set
+	// by ValidateEmailDomain.
+	MXHosts []string
+
+	// AuthoritativeIPs are the addresses served by the domain's own
+	// nameservers and AuthoritativeMismatch reports whether they differ
+	// from the recursive answer, as with a stale cache. Both are only set
+	// when Client.QueryAuthoritative is enabled.
+	AuthoritativeIPs      []string
+	AuthoritativeMismatch bool
+
+	// BehindCloudflare is set when IPAddress is a Cloudflare edge, in
+	// which case Location describes the edge and not the origin.
+	BehindCloudflare bool
 }
 
 type LocationDetails struct {
-	IP          string  `json:"ip"`
-	City        string  `json:"city,omitempty"`
-	Region      string  `json:"region,omitempty"`
-	Country     string  `json:"country_name,omitempty"`
-	Latitude    float64 `json:"latitude,omitempty"`
-	Longitude   float64 `json:"longitude,omitempty"`
+	IP        string  `json:"ip,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Region    string  `json:"region,omitempty"`
+	Country   string  `json:"country_name,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+
+	CountryCode            string `json:"country_code,omitempty"`
+	PostalCode             string `json:"postal,omitempty"`
+	ContinentCode          string `json:"continent_code,omitempty"`
+	Continent              string `json:"continent,omitempty"`
+	ASN                    string `json:"asn,omitempty"`
+	Org                    string `json:"org,omitempty"`
+	ApproximateCoordinates bool   `json:"approximate_coordinates,omitempty"`
+	Stale                  bool   `json:"stale,omitempty"`
+
+	// Anycast is set when the IP is in a well-known anycast prefix, so the
+	// coordinates describe only one of many edge locations.
+	Anycast bool `json:"anycast,omitempty"`
+
+	// AccuracyRadiusKm is the provider's uncertainty radius around the
+	// coordinates, or zero when the provider does not report one.
+	AccuracyRadiusKm int `json:"accuracy_radius,omitempty"`
+
+	// CoordinateDisagreementKm is the largest distance between the
+	// coordinates reported by different providers under StrategyMerge.
+	CoordinateDisagreementKm float64  `json:"coordinate_disagreement_km,omitempty"`
+	Warnings                 []string `json:"warnings,omitempty"`
 }
 
 func ValidateDomain(input string) (*DomainInfo, error) {
-	cleanDomain := cleanDomainInput(input)
+	return defaultClient.ValidateDomain(context.Background(), input)
+}
+
+func (c *Client) ValidateDomain(ctx context.Context, input string) (info *DomainInfo, err error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 
-	if !isValidDomainFormat(cleanDomain) {
-		return nil, fmt.Errorf("invalid domain format")
+	ctx, span := c.startSpan(ctx, "domaininfo.ValidateDomain", "input", input)
+	defer func() { endSpan(span, err) }()
+
+	info, err = c.Resolve(ctx, input)
+	if err != nil {
+		return nil, err
 	}
+	span.SetAttribute("domain", info.CleanDomain)
+	span.SetAttribute("ip", info.IPAddress)
 
-	if !checkDNSResolution(cleanDomain) {
-		return nil, fmt.Errorf("cannot resolve domain")
+	if err := c.Enrich(ctx, info); err != nil {
+		return nil, err
 	}
 
-	ipAddress, err := getIPAddress(cleanDomain)
-	if err != nil {
-		return nil, fmt.Errorf("unable to resolve IP: %v", err)
+	return info, nil
+}
+
+func Resolve(input string) (*DomainInfo, error) {
+	return defaultClient.Resolve(context.Background(), input)
+}
+
+// Resolve cleans and validates input and resolves its IP address without
+// fetching geolocation data.
+func (c *Client) Resolve(ctx context.Context, input string) (*DomainInfo, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	host, port := c.runCleanHooks(input), 0
+	if c.AllowPort {
+		var err error
+		if host, port, err = splitEndpoint(host); err != nil {
+			return nil, err
+		}
 	}
 
-	location, err := getIPLocation(ipAddress)
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch location: %v", err)
+	cleanDomain := c.normalizeHost(host)
+	wasWWW := isWWWAlias(host)
+
+	var timing *Timing
+	if c.RecordTiming {
+		timing = &Timing{}
+	}
+
+	// IP-literal hosts go straight to geolocation.
+	if ip := net.ParseIP(cleanDomain); ip != nil {
+		return &DomainInfo{
+			OriginalInput: input,
+			CleanDomain:   cleanDomain,
+			UnicodeDomain: cleanDomain,
+			IPAddress:     cleanDomain,
+			IPAddresses:   []string{cleanDomain},
+			Port:          port,
+			IsIPLiteral:   true,
+			ResolvedBy:    "literal",
+			Timing:        timing,
+
+			ResolutionMethod: "literal",
+
+			BehindCloudflare: IsCloudflareIP(cleanDomain),
+		}, nil
+	}
+
+	if err := c.checkDomain(cleanDomain); err != nil {
+		return nil, err
+	}
+
+	if c.FixtureFile != "" {
+		if info, ok, err := c.resolveFixture(input, cleanDomain); ok {
+			return info, err
+		}
+	}
+
+	if c.NegativeCacheTTL > 0 && !isFreshLookup(ctx) {
+		if err := c.negCache.get(strings.ToLower(cleanDomain)); err != nil {
+			return nil, err
+		}
 	}
 
-	return &DomainInfo{
+	start := time.Now()
+	if err := c.checkDNSResolution(ctx, cleanDomain); err != nil {
+		if c.NegativeCacheTTL > 0 && errors.Is(err, ErrDomainNotFound) {
+			c.negCache.set(strings.ToLower(cleanDomain), err, c.NegativeCacheTTL)
+		}
+		return nil, err
+	}
+	if timing != nil {
+		timing.DNS = time.Since(start)
+	}
+
+	start = time.Now()
+	ipAddresses, resolvedBy, err := c.getIPAddress(ctx, cleanDomain)
+	if err != nil || len(ipAddresses) == 0 {
+		return nil, fmt.Errorf("unable to resolve IP: %w", err)
+	}
+	if timing != nil {
+		timing.IPLookup = time.Since(start)
+	}
+
+	if err := c.checkExpectedRanges(ipAddresses); err != nil {
+		return nil, err
+	}
+
+	info := &DomainInfo{
 		OriginalInput: input,
 		CleanDomain:   cleanDomain,
-		IPAddress:     ipAddress,
-		Location:      location,
-	}, nil
+		UnicodeDomain: ToUnicode(cleanDomain),
+		IPAddress:     ipAddresses[0],
+		IPAddresses:   ipAddresses,
+		Port:          port,
+		WasWWW:        wasWWW,
+		ResolvedBy:    resolvedBy,
+		Timing:        timing,
+
+		ResolutionMethod: c.resolutionMethod(resolvedBy),
+		BehindCloudflare: IsCloudflareIP(ipAddresses[0]),
+	}
+	c.compareAuthoritative(ctx, info)
+	return info, nil
+}
+
+func ValidateWithIP(domain, ip string) (*DomainInfo, error) {
+	return defaultClient.ValidateWithIP(context.Background(), domain, ip)
+}
+
+// ValidateWithIP validates the format of domain like ValidateDomain but
+// skips DNS, geolocating the supplied ip instead. It suits pipelines that
+// resolved the domain upstream, or pinning a domain to a specific address.
+func (c *Client) ValidateWithIP(ctx context.Context, domain, ip string) (*DomainInfo, error) {
+	cleanDomain := c.normalizeDomain(domain)
+	if err := c.checkDomain(cleanDomain); err != nil {
+		return nil, err
+	}
+
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return nil, ErrInvalidIP
+	}
+
+	info := &DomainInfo{
+		OriginalInput: domain,
+		CleanDomain:   cleanDomain,
+		UnicodeDomain: ToUnicode(cleanDomain),
+		IPAddress:     parsed.String(),
+		IPAddresses:   []string{parsed.String()},
+		WasWWW:        isWWWAlias(domain),
+		ResolvedBy:    "supplied",
+
+		ResolutionMethod: "supplied",
+		BehindCloudflare: IsCloudflareIP(parsed.String()),
+	}
+	if err := c.Enrich(ctx, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// normalizeDomain runs the CleanHooks on input, extracts the domain and
+// converts it to the punycode form used for DNS.
+func (c *Client) normalizeDomain(input string) string {
+	return c.normalizeHost(c.runCleanHooks(input))
+}
+
+// runCleanHooks passes input through each of Client.CleanHooks in order.
+func (c *Client) runCleanHooks(input string) string {
+	for _, hook := range c.CleanHooks {
+		input = hook(input)
+	}
+	return input
+}
+
+// normalizeHost is normalizeDomain for input the CleanHooks have already
+// processed.
+func (c *Client) normalizeHost(input string) string {
+	domain := cleanDomainInput(input)
+	if c.Canonicalize {
+		domain = canonicalizeDomain(domain)
+	}
+	if !isASCII(domain) {
+		domain = ToASCII(domain)
+	}
+	return domain
+}
+
+// checkDomain runs the offline checks shared by every validation path.
+func (c *Client) checkDomain(domain string) error {
+	if net.ParseIP(domain) != nil {
+		return ErrInputIsIP
+	}
+
+	if err := checkDomainLength(domain); err != nil {
+		return err
+	}
+
+	if !isValidDomainFormat(domain) {
+		return ErrInvalidDomainFormat
+	}
+
+	return c.checkTLDPolicy(domain)
+}
+
+func Enrich(info *DomainInfo) error {
+	return defaultClient.Enrich(context.Background(), info)
+}
+
+// Enrich fetches geolocation data for info.IPAddress and stores it in
+// info.Location.
+func (c *Client) Enrich(ctx context.Context, info *DomainInfo) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if info.ResolvedBy == fixtureResolver {
+		if info.Location != nil {
+			return nil
+		}
+		if !c.FixtureFallthrough {
+			return fmt.Errorf("unable to fetch location: %w: no location for %s", ErrNotInFixture, info.CleanDomain)
+		}
+	}
+
+	var host string
+	if c.GeoByHostname {
+		host = info.CleanDomain
+	}
+
+	if c.RecordTiming && info.Timing == nil {
+		info.Timing = &Timing{}
+	}
+
+	start := time.Now()
+	location, err := c.getIPLocation(withTiming(ctx, info.Timing), info.IPAddress, host)
+	if info.Timing != nil {
+		info.Timing.Geo = time.Since(start)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to fetch location: %w", err)
+	}
+
+	info.Location = location
+	return nil
 }
 
+// cleanDomainInput extracts the host from input. Any URL with a scheme,
+// such as ftp:// or ws://, or a scheme-relative //host is reduced to its
+// hostname, dropping credentials, port and path.
 func cleanDomainInput(input string) string {
-	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
-		parsedURL, err := url.Parse(input)
-		if err == nil {
+	input = strings.TrimSpace(input)
+	if strings.Contains(input, "://") || strings.HasPrefix(input, "//") {
+		if parsedURL, err := url.Parse(input); err == nil && parsedURL.Host != "" {
 			input = parsedURL.Hostname()
 		}
 	}
 
 	input = strings.TrimPrefix(input, "www.")
-	return strings.TrimSpace(input)
+	if host, ok := ipLiteralHost(input); ok {
+		return host
+	}
+	return input
+}
+
+// ipLiteralHost recognizes an IP literal written as a URL host would be:
+// bracketed IPv6 such as "[::1]", optionally with a port as in
+// "[::1]:8080" or "192.168.0.1:8080".
+func ipLiteralHost(input string) (string, bool) {
+	host := input
+	if h, _, err := net.SplitHostPort(input); err == nil {
+		host = h
+	} else if strings.HasPrefix(input, "[") && strings.HasSuffix(input, "]") {
+		host = input[1 : len(input)-1]
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String(), true
+	}
+	return "", false
+}
+
+func isWWWAlias(input string) bool {
+	input = strings.TrimSpace(input)
+	if parsedURL, err := url.Parse(input); err == nil && parsedURL.Host != "" {
+		input = parsedURL.Hostname()
+	}
+	return strings.HasPrefix(strings.ToLower(input), "www.")
+}
+
+func canonicalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	return strings.TrimPrefix(domain, "www.")
 }
 
+// domainRegex also accepts punycode (xn--) labels after the first, so
+// internationalized domains validate once converted with ToASCII.
+var domainRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.([a-zA-Z]{2,}|[xX][nN]--[a-zA-Z0-9-]{1,59}))+$`)
+
 func isValidDomainFormat(domain string) bool {
-	domainRegex := regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z]{2,})+$`)
 	return domainRegex.MatchString(domain)
 }
 
-func getIPAddress(domain string) (string, error) {
-	ips, err := net.LookupIP(domain)
-	if err != nil || len(ips) == 0 {
-		return "", err
+func checkDomainLength(domain string) error {
+	if len(strings.TrimSuffix(domain, ".")) > 253 {
+		return ErrDomainTooLong
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) > 63 {
+			return ErrLabelTooLong
+		}
 	}
-	return ips[0].String(), nil
+
+	return nil
 }
 
-func checkDNSResolution(domain string) bool {
-	_, err := net.LookupIP(domain)
-	return err == nil
+func (c *Client) getIPAddress(ctx context.Context, domain string) (ips []string, resolvedBy string, err error) {
+	ctx, span := c.startSpan(ctx, "domaininfo.IPLookup", "domain", domain)
+	defer func() { endSpan(span, err) }()
+
+	resolved, resolvedBy, err := c.lookupIP(ctx, domain)
+	if err != nil || len(resolved) == 0 {
+		return nil, "", err
+	}
+
+	for _, ip := range resolved {
+		ips = append(ips, ip.String())
+	}
+	span.SetAttribute("ip", ips[0])
+	span.SetAttribute("resolver", resolvedBy)
+	return ips, resolvedBy, nil
 }
 
-func getIPLocation(ip string) (*LocationDetails, error) {
-	locationProviders := []func(string) (*LocationDetails, error){
-		getIPAPILocation,
-		getIPInfoLocation,
-		getFreeGeoIPLocation,
+func (c *Client) checkDNSResolution(ctx context.Context, domain string) (err error) {
+	ctx, span := c.startSpan(ctx, "domaininfo.DNSResolution", "domain", domain)
+	defer func() { endSpan(span, err) }()
+
+	err = c.retry(ctx, isTransientDNSError, func() error {
+		_, _, err := c.lookupIP(ctx, domain)
+		return err
+	})
+	return classifyDNSError(err)
+}
+
+// getIPLocation geolocates ip. When host is non-empty it is sent instead of
+// ip to providers that resolve hostnames themselves.
+func (c *Client) getIPLocation(ctx context.Context, ip, host string) (location *LocationDetails, err error) {
+	ctx, span := c.startSpan(ctx, "domaininfo.Geolocate", "ip", ip)
+	defer func() { endSpan(span, err) }()
+
+	key := geoCacheKey(ip, host)
+	cached, fresh, found := c.geoCache.get(key)
+	bypass := isFreshLookup(ctx)
+	if found && fresh && c.CacheTTL > 0 && !bypass {
+		span.SetAttribute("cache", "hit")
+		return cached, nil
 	}
 
-	for _, provider := range locationProviders {
-		location, err := provider(ip)
-		if err == nil && location != nil {
-			return location, nil
+	// Fresh lookups only share in-flight requests with each other, never
+	// with one that may have started before the caller's change.
+	flightKey := key
+	if bypass {
+		flightKey = "fresh|" + key
+	}
+	shared, err := c.geoFlight.do(ctx, flightKey, func(fctx context.Context) (*LocationDetails, error) {
+		qctx, cancel := c.withTimeout(fctx)
+		defer cancel()
+		location, err := c.queryProviders(qctx, span, ip, host)
+		if err == nil && (c.CacheTTL > 0 || c.StaleOnError) {
+			c.geoCache.set(key, location, c.CacheTTL, c.CacheSize)
 		}
+		return location, err
+	})
+	if err == nil {
+		return copyLocation(shared), nil
 	}
 
-	return nil, fmt.Errorf("could not fetch location from any provider")
+	if found && c.StaleOnError {
+		span.SetAttribute("cache", "stale")
+		cached.Stale = true
+		return cached, nil
+	}
+
+	return nil, err
 }
 
-func getIPAPILocation(ip string) (*LocationDetails, error) {
-	resp, err := http.Get(fmt.Sprintf("https://ipapi.co/%s/json/", ip))
+func (c *Client) queryProviders(ctx context.Context, span Span, ip, host string) (*LocationDetails, error) {
+	var location *LocationDetails
+	var err error
+	switch c.Strategy {
+	case StrategyMerge:
+		location, err = c.queryMerge(ctx, span, ip, host)
+	case StrategyHedged:
+		location, err = c.queryHedged(ctx, span, ip, host)
+	default:
+		location, err = c.queryFirst(ctx, span, ip, host)
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	if c.CentroidFallback {
+		fillCentroid(location)
+	}
+	fillContinent(location)
+	markAnycast(location, ip)
+	return location, nil
+}
+
+func (c *Client) queryFirst(ctx context.Context, span Span, ip, host string) (*LocationDetails, error) {
+	var lastErr error
+	for _, provider := range c.providers(ip) {
+		location, err := c.attemptProvider(ctx, provider, ip, host)
+		if err == nil {
+			span.SetAttribute("provider", provider.Name)
+			return location, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+
+	return nil, providersFailed(lastErr)
+}
+
+func (c *Client) attemptProvider(ctx context.Context, provider Provider, ip, host string) (location *LocationDetails, err error) {
+	target := ip
+	if host != "" && provider.ByHostname {
+		target = host
+	}
+
+	if err := c.fitsDeadline(ctx, provider.Name); err != nil {
+		return nil, err
+	}
+	// Transient failures such as 429, 5xx or empty bodies are retried under
+	// Client.Retry, drawing on the same budget and jitter as DNS retries.
+	err = c.retry(ctx, IsRetryable, func() error {
+		if err := c.geoLimiter.wait(ctx, c.RateLimit); err != nil {
+			return err
+		}
+
+		pctx, pspan := c.startSpan(ctx, "domaininfo.Provider", "ip", ip, "provider", provider.Name, "target", target)
+		start := time.Now()
+		var err error
+		location, err = provider.Locate(pctx, target)
+		elapsed := time.Since(start)
+		recordProviderTiming(ctx, provider.Name, elapsed)
+		if err == nil && location == nil {
+			err = fmt.Errorf("no location data")
+		}
+		if err == nil && len(c.RequiredFields) > 0 {
+			if missing := missingFields(location, c.RequiredFields); len(missing) > 0 {
+				location, err = nil, fmt.Errorf("%s result missing required fields: %s", provider.Name, strings.Join(missing, ", "))
+			}
+		}
+		if ctx.Err() == nil {
+			c.recordAttempt(provider.Name, elapsed, err)
+		}
+		endSpan(pspan, err)
+		return err
+	})
+	if err != nil {
+		location = nil
+	}
+
+	return location, err
+}
+
+func (c *Client) getIPAPILocation(ctx context.Context, ip string) (*LocationDetails, error) {
+	body, err := c.fetch(ctx, "ipapi", fmt.Sprintf("https://ipapi.co/%s/json/", ip))
 	if err != nil {
 		return nil, err
 	}
@@ -128,14 +586,8 @@ func getIPAPILocation(ip string) (*LocationDetails, error) {
 	return &location, nil
 }
 
-func getIPInfoLocation(ip string) (*LocationDetails, error) {
-	resp, err := http.Get(fmt.Sprintf("https://ipinfo.io/%s/json", ip))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) getIPInfoLocation(ctx context.Context, ip string) (*LocationDetails, error) {
+	body, err := c.fetch(ctx, "ipinfo", fmt.Sprintf("https://ipinfo.io/%s/json", ip))
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +609,15 @@ func getIPInfoLocation(ip string) (*LocationDetails, error) {
 
 	location.City, _ = data["city"].(string)
 	location.Region, _ = data["region"].(string)
+	location.PostalCode, _ = data["postal"].(string)
 	location.Country, _ = data["country"].(string)
+	location.CountryCode = location.Country
+	if org, ok := data["org"].(string); ok {
+		location.ASN, location.Org = splitASNOrg(org)
+	}
+	if radius, ok := data["accuracy_radius"].(float64); ok {
+		location.AccuracyRadiusKm = int(radius)
+	}
 
 	if location.City == "" && location.Country == "" {
 		return nil, fmt.Errorf("no location data")
@@ -166,14 +626,18 @@ func getIPInfoLocation(ip string) (*LocationDetails, error) {
 	return location, nil
 }
 
-func getFreeGeoIPLocation(ip string) (*LocationDetails, error) {
-	resp, err := http.Get(fmt.Sprintf("https://freegeoip.app/json/%s", ip))
-	if err != nil {
-		return nil, err
+func splitASNOrg(org string) (asn, name string) {
+	if strings.HasPrefix(org, "AS") {
+		if i := strings.IndexByte(org, ' '); i > 0 {
+			return org[:i], org[i+1:]
+		}
+		return org, ""
 	}
-	defer resp.Body.Close()
+	return "", org
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) getFreeGeoIPLocation(ctx context.Context, ip string) (*LocationDetails, error) {
+	body, err := c.fetch(ctx, "freegeoip", fmt.Sprintf("https://freegeoip.app/json/%s", ip))
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +648,14 @@ func getFreeGeoIPLocation(ip string) (*LocationDetails, error) {
 		return nil, err
 	}
 
+	// freegeoip names the postal code zip_code.
+	var extra struct {
+		ZipCode string `json:"zip_code"`
+	}
+	if json.Unmarshal(body, &extra) == nil && location.PostalCode == "" {
+		location.PostalCode = extra.ZipCode
+	}
+
 	if location.City == "" && location.Country == "" {
 		return nil, fmt.Errorf("no location data")
 	}
@@ -0,0 +1,143 @@
+package domaininfo
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDNS serves A queries for every name with addr from a local UDP
+// socket, answering other types with no records, and returns a resolver
+// that uses it.
+func fakeDNS(t *testing.T, addr net.IP) *net.Resolver {
+	server, _ := fakeDNSServer(t, addr)
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", server)
+		},
+	}
+}
+
+// fakeDNSServer starts the server behind fakeDNS and returns its address
+// and a function listing the query names it has received.
+func fakeDNSServer(t *testing.T, addr net.IP) (string, func() []string) {
+	var mu sync.Mutex
+	var names []string
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, peer, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < 12 {
+				continue
+			}
+
+			// Find the end of the question to read its type.
+			end := 12
+			for end < n && buf[end] != 0 {
+				end += int(buf[end]) + 1
+			}
+			end += 5
+			if end > n {
+				continue
+			}
+			qtype := binary.BigEndian.Uint16(buf[end-4:])
+
+			var labels []string
+			for i := 12; i < end-5; i += int(buf[i]) + 1 {
+				labels = append(labels, string(buf[i+1:i+1+int(buf[i])]))
+			}
+			mu.Lock()
+			names = append(names, strings.Join(labels, "."))
+			mu.Unlock()
+
+			resp := append([]byte(nil), buf[:end]...)
+			binary.BigEndian.PutUint16(resp[2:], 0x8180) // response, RD, RA
+			binary.BigEndian.PutUint16(resp[6:], 0)      // ANCOUNT
+			binary.BigEndian.PutUint16(resp[8:], 0)      // NSCOUNT
+			binary.BigEndian.PutUint16(resp[10:], 0)     // ARCOUNT
+			if qtype == 1 {
+				binary.BigEndian.PutUint16(resp[6:], 1)
+				resp = append(resp, 0xc0, 0x0c, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+				resp = append(resp, addr.To4()...)
+			}
+			conn.WriteTo(resp, peer)
+		}
+	}()
+
+	return conn.LocalAddr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), names...)
+	}
+}
+
+func TestResolveAndEnrichHermetic(t *testing.T) {
+	geo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/192.0.2.10" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"city":"Lisbon","country":"Portugal","cc":"PT","lat":38.72,"lon":-9.14}`))
+	}))
+	defer geo.Close()
+
+	client := &Client{
+		Resolver:   fakeDNS(t, net.ParseIP("192.0.2.10")),
+		HTTPClient: geo.Client(),
+	}
+	provider, err := client.MappedProvider("fake", geo.URL+"/{ip}", map[string]string{
+		"city":    "city",
+		"country": "country_name",
+		"cc":      "country_code",
+		"lat":     "latitude",
+		"lon":     "longitude",
+	})
+	if err != nil {
+		t.Fatalf("MappedProvider: %v", err)
+	}
+	client.Providers = []Provider{provider}
+
+	info, err := client.Resolve(context.Background(), "https://www.shop.example.com/path")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if info.CleanDomain != "shop.example.com" || info.IPAddress != "192.0.2.10" {
+		t.Errorf("Resolve = %s at %s, want shop.example.com at 192.0.2.10", info.CleanDomain, info.IPAddress)
+	}
+	if info.ResolutionMethod != "system" {
+		t.Errorf("ResolutionMethod = %q, want system", info.ResolutionMethod)
+	}
+
+	if err := client.Enrich(context.Background(), info); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if info.Location.City != "Lisbon" || info.Location.CountryCode != "PT" {
+		t.Errorf("Location = %+v, want Lisbon, PT", info.Location)
+	}
+}
+
+func TestResolveRejectsInvalidDomainOffline(t *testing.T) {
+	client := &Client{Resolver: fakeDNS(t, net.ParseIP("192.0.2.10"))}
+	for _, input := range []string{"not a domain", "-bad.example.com", "example"} {
+		if _, err := client.Resolve(context.Background(), input); err == nil {
+			t.Errorf("Resolve(%q) succeeded, want error", input)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package domaininfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MappedProvider returns a Provider for a JSON geolocation API described
+// by a field mapping instead of Go parsing code. urlTemplate is the request
+// URL with "{ip}" standing for the queried address. fields maps keys of the
+// response, using dots for nested objects as in "location.lat", to
+// LocationDetails fields by JSON or Go name:
+//
+//	client.MappedProvider("geoapi", "https://geo.example/{ip}", map[string]string{
+//		"lat":  "latitude",
+//		"lon":  "longitude",
+//		"cc":   "country_code",
+//		"city": "city",
+//	})
+//
+// Numbers and strings are converted to the field's type. A response that
+// fills none of city, country name or country code is rejected as "no
+// location data", like the built-in providers do, so the next provider is
+// tried. Query parameters from ProviderParams are added under name.
+func (c *Client) MappedProvider(name, urlTemplate string, fields map[string]string) (Provider, error) {
+	if !strings.Contains(urlTemplate, "{ip}") {
+		return Provider{}, fmt.Errorf("%s: URL template has no {ip} placeholder", name)
+	}
+
+	indexes := make(map[string]int, len(fields))
+	for key, field := range fields {
+		i := locationFieldIndex(field)
+		if i < 0 {
+			return Provider{}, fmt.Errorf("%s: unknown LocationDetails field %q", name, field)
+		}
+		indexes[key] = i
+	}
+
+	return Provider{
+		Name:         name,
+		SupportsIPv6: true,
+		Locate: func(ctx context.Context, ip string) (*LocationDetails, error) {
+			body, err := c.fetch(ctx, name, strings.ReplaceAll(urlTemplate, "{ip}", ip))
+			if err != nil {
+				return nil, err
+			}
+
+			var data map[string]interface{}
+			if err := json.Unmarshal(body, &data); err != nil {
+				return nil, err
+			}
+
+			location := &LocationDetails{IP: ip}
+			v := reflect.ValueOf(location).Elem()
+			for key, i := range indexes {
+				value, ok := jsonPath(data, key)
+				if !ok || value == nil {
+					continue
+				}
+				if err := setMappedField(v.Field(i), value); err != nil {
+					return nil, fmt.Errorf("%s: field %q: %v", name, key, err)
+				}
+			}
+
+			if location.City == "" && location.Country == "" && location.CountryCode == "" {
+				return nil, fmt.Errorf("no location data")
+			}
+			return location, nil
+		},
+	}, nil
+}
+
+// jsonPath looks up a dotted key such as "location.lat" in decoded JSON.
+func jsonPath(data map[string]interface{}, key string) (interface{}, bool) {
+	var value interface{} = data
+	for _, part := range strings.Split(key, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// setMappedField stores a decoded JSON value in a LocationDetails field,
+// converting between numbers and strings where needed.
+func setMappedField(field reflect.Value, value interface{}) error {
+	switch field.Kind() {
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			field.SetString(v)
+		case float64:
+			field.SetString(strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			return fmt.Errorf("cannot use %T as string", value)
+		}
+	case reflect.Float64, reflect.Int:
+		var f float64
+		switch v := value.(type) {
+		case float64:
+			f = v
+		case string:
+			var err error
+			if f, err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+				return fmt.Errorf("cannot parse %q as a number", v)
+			}
+		default:
+			return fmt.Errorf("cannot use %T as a number", value)
+		}
+		if field.Kind() == reflect.Int {
+			field.SetInt(int64(f))
+		} else {
+			field.SetFloat(f)
+		}
+	case reflect.Bool:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("cannot use %T as bool", value)
+		}
+		field.SetBool(v)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
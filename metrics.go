@@ -0,0 +1,87 @@
+package domaininfo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// ProviderMetric is a snapshot of one provider's stats, shaped for metrics
+// systems. Buckets maps each latency upper bound in seconds to the
+// cumulative number of attempts at or below it, as Prometheus histograms
+// expect, so a prometheus.Collector can pass it straight to
+// MustNewConstHistogram without this package depending on Prometheus.
+type ProviderMetric struct {
+	Provider          string
+	Attempts          uint64
+	Successes         uint64
+	Failures          uint64
+	LatencySumSeconds float64
+	Buckets           map[float64]uint64
+}
+
+func ProviderMetrics() []ProviderMetric {
+	return defaultClient.ProviderMetrics()
+}
+
+// ProviderMetrics returns a snapshot of every provider the client has
+// attempted, sorted by provider name.
+func (c *Client) ProviderMetrics() []ProviderMetric {
+	var metrics []ProviderMetric
+	c.stats.Range(func(key, value any) bool {
+		stats := value.(*providerStats)
+		metric := ProviderMetric{
+			Provider:          key.(string),
+			Successes:         uint64(stats.successes.Load()),
+			Failures:          uint64(stats.failures.Load()),
+			LatencySumSeconds: float64(stats.totalNanos.Load()) / 1e9,
+			Buckets:           make(map[float64]uint64, len(latencyBuckets)),
+		}
+		metric.Attempts = metric.Successes + metric.Failures
+
+		// Writers update the counters without a lock, counting the outcome
+		// before the bucket. Reading the total first and clamping the
+		// buckets to it keeps the snapshot a valid cumulative histogram.
+		var cumulative uint64
+		for i, bound := range latencyBuckets {
+			cumulative += uint64(stats.buckets[i].Load())
+			metric.Buckets[bound] = min(cumulative, metric.Attempts)
+		}
+		metrics = append(metrics, metric)
+		return true
+	})
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Provider < metrics[j].Provider })
+	return metrics
+}
+
+// WritePrometheus writes the client's provider metrics to w in the
+// Prometheus text exposition format, for serving from a /metrics handler
+// without a Prometheus client library.
+func (c *Client) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	metrics := c.ProviderMetrics()
+
+	fmt.Fprintln(bw, "# HELP domaininfo_provider_attempts_total Geolocation provider attempts by outcome.")
+	fmt.Fprintln(bw, "# TYPE domaininfo_provider_attempts_total counter")
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "domaininfo_provider_attempts_total{provider=%q,outcome=\"success\"} %d\n", m.Provider, m.Successes)
+		fmt.Fprintf(bw, "domaininfo_provider_attempts_total{provider=%q,outcome=\"failure\"} %d\n", m.Provider, m.Failures)
+	}
+
+	fmt.Fprintln(bw, "# HELP domaininfo_provider_latency_seconds Geolocation provider response time.")
+	fmt.Fprintln(bw, "# TYPE domaininfo_provider_latency_seconds histogram")
+	for _, m := range metrics {
+		for _, bound := range latencyBuckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			fmt.Fprintf(bw, "domaininfo_provider_latency_seconds_bucket{provider=%q,le=%q} %d\n", m.Provider, le, m.Buckets[bound])
+		}
+		fmt.Fprintf(bw, "domaininfo_provider_latency_seconds_bucket{provider=%q,le=\"+Inf\"} %d\n", m.Provider, m.Attempts)
+		fmt.Fprintf(bw, "domaininfo_provider_latency_seconds_sum{provider=%q} %g\n", m.Provider, m.LatencySumSeconds)
+		fmt.Fprintf(bw, "domaininfo_provider_latency_seconds_count{provider=%q} %d\n", m.Provider, m.Attempts)
+	}
+
+	return bw.Flush()
+}
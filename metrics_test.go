@@ -0,0 +1,47 @@
+package domaininfo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProviderMetricsConsistentUnderLoad(t *testing.T) {
+	client := &Client{}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				var err error
+				if i%3 == 0 {
+					err = errors.New("failed")
+				}
+				client.recordAttempt("geo", time.Duration(i%50)*time.Millisecond, err)
+			}
+		}(w)
+	}
+
+	for i := 0; i < 1000; i++ {
+		for _, metric := range client.ProviderMetrics() {
+			var prev uint64
+			for _, bound := range latencyBuckets {
+				count := metric.Buckets[bound]
+				if count < prev || count > metric.Attempts {
+					t.Fatalf("bucket %g = %d after %d, with %d attempts", bound, count, prev, metric.Attempts)
+				}
+				prev = count
+			}
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,89 @@
+package domaininfo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+func NeighborDomains(ip string) ([]string, error) {
+	return defaultClient.NeighborDomains(context.Background(), ip)
+}
+
+// NeighborDomains queries Client.ReverseIPURL for the domains hosted on ip,
+// returning them lowercased, deduplicated and sorted. The endpoint may
+// answer with a JSON array of names, passive DNS Common Output Format
+// records (as passive DNS services do for reverse lookups) or plain text
+// with one name per line. It returns ErrReverseIPDisabled unless both
+// ReverseIPURL and ReverseIPKey are set.
+func (c *Client) NeighborDomains(ctx context.Context, ip string) ([]string, error) {
+	if c.ReverseIPURL == "" || c.ReverseIPKey == "" {
+		return nil, ErrReverseIPDisabled
+	}
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return nil, ErrInvalidIP
+	}
+
+	rawURL := strings.TrimSuffix(c.ReverseIPURL, "/") + "/" + url.PathEscape(parsed.String())
+	body, err := c.fetchWithKey(ctx, "reverseip", rawURL, c.ReverseIPKey)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	names, err := parseNeighborNames(body)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(names))
+	var domains []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+		if name == "" || seen[name] || net.ParseIP(name) != nil {
+			continue
+		}
+		seen[name] = true
+		domains = append(domains, name)
+	}
+	sort.Strings(domains)
+	return domains, nil
+}
+
+func parseNeighborNames(body []byte) ([]string, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	if body[0] == '[' {
+		var names []string
+		if err := json.Unmarshal(body, &names); err == nil {
+			return names, nil
+		}
+	}
+
+	if body[0] == '[' || body[0] == '{' {
+		records, err := parseCOF(body)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(records))
+		for _, record := range records {
+			names = append(names, record.RRName)
+		}
+		return names, nil
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		names = append(names, scanner.Text())
+	}
+	return names, scanner.Err()
+}
@@ -0,0 +1,104 @@
+package domaininfo
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// parkingPhrases are the markers parking providers put on their pages.
+var parkingPhrases = []string{
+	"this domain is for sale",
+	"buy this domain",
+	"domain is for sale",
+	"this domain may be for sale",
+	"parked free",
+	"domain parking",
+	"inquire about this domain",
+}
+
+// genericParkingPhrases are common on parked pages but also appear on
+// ordinary sites, so each weighs less and one alone is not enough.
+var genericParkingPhrases = []string{
+	"this domain has been registered",
+	"related searches",
+}
+
+var parkingNameservers = []string{
+	"sedoparking.com",
+	"bodis.com",
+	"parkingcrew.net",
+	"above.com",
+	"dan.com",
+	"afternic.com",
+	"parklogic.com",
+	"voodoo.com",
+	"namebrightdns.com",
+	"uniregistrymarket.link",
+}
+
+const (
+	parkedPhraseWeight     = 0.5
+	parkedGenericWeight    = 0.25
+	parkedNameserverWeight = 0.4
+	parkedSmallPageWeight  = 0.15
+	parkedThreshold        = 0.5
+	parkedSmallPageBytes   = 2048
+)
+
+func IsParked(domain string) (bool, error) {
+	return defaultClient.IsParked(context.Background(), domain)
+}
+
+// IsParked combines homepage content, nameserver and page-size signals into
+// a weighted score and reports whether the domain looks parked. A generic
+// phrase such as "related searches" only counts together with another
+// signal.
+func (c *Client) IsParked(ctx context.Context, domain string) (bool, error) {
+	domain = cleanDomainInput(domain)
+
+	var score float64
+	ns, nsErr := c.resolver().LookupNS(ctx, domain)
+	for _, n := range ns {
+		if matchesParkingNameserver(n.Host) {
+			score += parkedNameserverWeight
+			break
+		}
+	}
+
+	page, pageErr := c.fetchHomepage(ctx, domain)
+	if pageErr == nil {
+		body := bytes.ToLower(page.Body)
+		for _, phrase := range parkingPhrases {
+			if bytes.Contains(body, []byte(phrase)) {
+				score += parkedPhraseWeight
+				break
+			}
+		}
+		for _, phrase := range genericParkingPhrases {
+			if bytes.Contains(body, []byte(phrase)) {
+				score += parkedGenericWeight
+			}
+		}
+
+		if len(page.Body) < parkedSmallPageBytes {
+			score += parkedSmallPageWeight
+		}
+	}
+
+	if nsErr != nil && pageErr != nil {
+		return false, pageErr
+	}
+
+	return score >= parkedThreshold, nil
+}
+
+func matchesParkingNameserver(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, suffix := range parkingNameservers {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package domaininfo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsParked(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "provider marker", body: "<h1>Buy this domain</h1>", want: true},
+		{name: "one generic phrase", body: "<p>Related searches: shoes, boots</p>"},
+		{name: "one generic phrase large page", body: "<p>Related searches</p>" + strings.Repeat("<p>article</p>", 500)},
+		{name: "two generic phrases", body: "<p>This domain has been registered.</p><p>Related searches</p>", want: true},
+		{name: "normal page", body: strings.Repeat("<p>article</p>", 500)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := &Client{
+				Resolver:   fakeDNS(t, net.ParseIP("192.0.2.1")),
+				HTTPClient: &http.Client{Transport: rewriteTransport{server}},
+			}
+			got, err := client.IsParked(context.Background(), "example.com")
+			if err != nil {
+				t.Fatalf("IsParked: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsParked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package domaininfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoricalRecord is an address a domain pointed to at some point, as
+// observed by a passive DNS sensor network.
+type HistoricalRecord struct {
+	IP        string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// cofRecord is one entry in the passive DNS Common Output Format
+// (draft-dulaunoy-dnsop-passive-dns-cof).
+type cofRecord struct {
+	RRName    string          `json:"rrname"`
+	RRType    string          `json:"rrtype"`
+	RData     json.RawMessage `json:"rdata"`
+	TimeFirst int64           `json:"time_first"`
+	TimeLast  int64           `json:"time_last"`
+}
+
+func HistoricalIPs(domain string) ([]HistoricalRecord, error) {
+	return defaultClient.HistoricalIPs(context.Background(), domain)
+}
+
+// HistoricalIPs queries Client.PassiveDNSURL for the A and AAAA records
+// domain has had, most recently seen first. The endpoint must answer in the
+// passive DNS Common Output Format, as CIRCL and DNSDB-compatible services
+// do. It returns ErrPassiveDNSDisabled when no endpoint is set.
+func (c *Client) HistoricalIPs(ctx context.Context, domain string) ([]HistoricalRecord, error) {
+	if c.PassiveDNSURL == "" {
+		return nil, ErrPassiveDNSDisabled
+	}
+	domain = ToASCII(cleanDomainInput(domain))
+
+	rawURL := strings.TrimSuffix(c.PassiveDNSURL, "/") + "/" + url.PathEscape(domain)
+	body, err := c.fetchWithKey(ctx, "passivedns", rawURL, c.PassiveDNSKey)
+	if err != nil || body == nil {
+		return nil, err
+	}
+	records, err := parseCOF(body)
+	if err != nil {
+		return nil, err
+	}
+	return historicalAddresses(records), nil
+}
+
+// fetchWithKey GETs rawURL from a keyed JSON API, sending key in the
+// X-API-Key header when set and tracking the quota headers under name. A
+// 404 answer returns a nil body and no error.
+func (c *Client) fetchWithKey(ctx context.Context, name, rawURL, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c.recordQuota(name, resp.Header)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, &StatusError{StatusCode: resp.StatusCode, URL: rawURL}
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+}
+
+// parseCOF accepts both the newline-delimited form of COF and a plain JSON
+// array of records.
+func parseCOF(body []byte) ([]cofRecord, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var records []cofRecord
+		err := json.Unmarshal(body, &records)
+		return records, err
+	}
+
+	var records []cofRecord
+	dec := json.NewDecoder(bytes.NewReader(body))
+	for {
+		var record cofRecord
+		if err := dec.Decode(&record); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+func historicalAddresses(records []cofRecord) []HistoricalRecord {
+	byIP := make(map[string]*HistoricalRecord)
+	for _, record := range records {
+		if rrtype := strings.ToUpper(record.RRType); rrtype != "A" && rrtype != "AAAA" {
+			continue
+		}
+
+		// rdata is a string, or an array of strings when records with the
+		// same name and type were aggregated.
+		var values []string
+		if err := json.Unmarshal(record.RData, &values); err != nil {
+			var value string
+			if json.Unmarshal(record.RData, &value) != nil {
+				continue
+			}
+			values = []string{value}
+		}
+
+		first, last := time.Unix(record.TimeFirst, 0).UTC(), time.Unix(record.TimeLast, 0).UTC()
+		for _, ip := range values {
+			h, ok := byIP[ip]
+			if !ok {
+				byIP[ip] = &HistoricalRecord{IP: ip, FirstSeen: first, LastSeen: last}
+				continue
+			}
+			if first.Before(h.FirstSeen) {
+				h.FirstSeen = first
+			}
+			if last.After(h.LastSeen) {
+				h.LastSeen = last
+			}
+		}
+	}
+
+	history := make([]HistoricalRecord, 0, len(byIP))
+	for _, h := range byIP {
+		history = append(history, *h)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if !history[i].LastSeen.Equal(history[j].LastSeen) {
+			return history[i].LastSeen.After(history[j].LastSeen)
+		}
+		return history[i].IP < history[j].IP
+	})
+	return history
+}
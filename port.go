@@ -0,0 +1,40 @@
+package domaininfo
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// splitEndpoint separates the port from a "host:port" endpoint or URL,
+// returning the input without the port and the port, or zero when input has
+// none.
+func splitEndpoint(input string) (string, int, error) {
+	input = strings.TrimSpace(input)
+	if strings.Contains(input, "://") || strings.HasPrefix(input, "//") {
+		parsedURL, err := url.Parse(input)
+		if err != nil || parsedURL.Port() == "" {
+			return input, 0, nil
+		}
+		port, err := parsePort(parsedURL.Port())
+		return input, port, err
+	}
+
+	host, portStr, err := net.SplitHostPort(input)
+	if err != nil {
+		// No port, or a bare IPv6 address.
+		return input, 0, nil
+	}
+	port, err := parsePort(portStr)
+	return host, port, err
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPort, s)
+	}
+	return port, nil
+}
@@ -0,0 +1,78 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// Provider is a geolocation source queried by the client. Locate receives
+// an IP address, or a hostname when ByHostname is set and
+// Client.GeoByHostname is enabled.
+type Provider struct {
+	Name   string
+	Locate func(ctx context.Context, target string) (*LocationDetails, error)
+
+	// ByHostname marks providers that accept a hostname in place of an IP
+	// and resolve it themselves.
+	ByHostname bool
+
+	// SupportsIPv6 marks providers that geolocate IPv6 addresses reliably.
+	// Providers without it are skipped for IPv6 lookups.
+	SupportsIPv6 bool
+}
+
+// DefaultProviders returns the built-in providers bound to c, in the order
+// they are tried: ipapi, ipinfo and freegeoip.
+func (c *Client) DefaultProviders() []Provider {
+	return []Provider{
+		{Name: "ipapi", Locate: c.getIPAPILocation, ByHostname: true, SupportsIPv6: true},
+		{Name: "ipinfo", Locate: c.getIPInfoLocation, ByHostname: true, SupportsIPv6: true},
+		{Name: "freegeoip", Locate: c.getFreeGeoIPLocation},
+	}
+}
+
+// providers returns the providers to query for ip, leaving out those that
+// do not support IPv6 when ip is an IPv6 address, ordered by reliability
+// when ReliabilityOrder is set.
+func (c *Client) providers(ip string) []Provider {
+	providers := c.Providers
+	if providers == nil {
+		providers = c.DefaultProviders()
+	}
+
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		var capable []Provider
+		for _, provider := range providers {
+			if provider.SupportsIPv6 {
+				capable = append(capable, provider)
+			}
+		}
+		providers = capable
+	}
+
+	if c.ReliabilityOrder {
+		return c.orderByReliability(providers)
+	}
+	return providers
+}
+
+// TestProvider returns a Provider that answers every lookup with a copy of
+// location, with IP set to the queried target, and makes no network
+// requests. A nil location makes every lookup fail. It is intended for
+// hermetic tests of code built on this package.
+func TestProvider(name string, location *LocationDetails) Provider {
+	return Provider{
+		Name:         name,
+		ByHostname:   true,
+		SupportsIPv6: true,
+		Locate: func(ctx context.Context, target string) (*LocationDetails, error) {
+			if location == nil {
+				return nil, fmt.Errorf("%s: no location data", name)
+			}
+			result := *location
+			result.IP = target
+			return &result, nil
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// rewriteTransport sends every request to server, whatever its original
+// host, so code with fixed provider URLs can be tested hermetically.
+type rewriteTransport struct {
+	server *httptest.Server
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, _ := url.Parse(t.server.URL)
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+	return t.server.Client().Transport.RoundTrip(req)
+}
+
+func TestTestProvider(t *testing.T) {
+	provider := TestProvider("fake", &LocationDetails{City: "Paris", CountryCode: "FR"})
+	location, err := provider.Locate(context.Background(), "192.0.2.1")
+	if err != nil {
+		t.Fatalf("Locate: %v", err)
+	}
+	if location.IP != "192.0.2.1" || location.City != "Paris" {
+		t.Errorf("Locate = %+v, want Paris for 192.0.2.1", location)
+	}
+
+	if _, err := TestProvider("broken", nil).Locate(context.Background(), "192.0.2.1"); err == nil {
+		t.Error("Locate with nil location succeeded, want error")
+	}
+}
+
+func TestProviderHTTPResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr func(error) bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"city":"Berlin","cc":"DE"}`,
+		},
+		{
+			name:    "empty body",
+			status:  http.StatusOK,
+			body:    "  \n",
+			wantErr: func(err error) bool { return errors.Is(err, ErrEmptyResponse) && IsRetryable(err) },
+		},
+		{
+			name:   "non-200",
+			status: http.StatusServiceUnavailable,
+			body:   `{"city":"Berlin"}`,
+			wantErr: func(err error) bool {
+				var statusErr *StatusError
+				return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusServiceUnavailable
+			},
+		},
+		{
+			name:    "error body",
+			status:  http.StatusOK,
+			body:    `{"error":"rate limited"}`,
+			wantErr: func(err error) bool { return err != nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client()}
+			provider, err := client.MappedProvider("geo", server.URL+"/{ip}", map[string]string{
+				"city": "city",
+				"cc":   "country_code",
+			})
+			if err != nil {
+				t.Fatalf("MappedProvider: %v", err)
+			}
+
+			location, err := provider.Locate(context.Background(), "192.0.2.1")
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("Locate error = %v, want a different error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Locate: %v", err)
+			}
+			if location.City != "Berlin" || location.CountryCode != "DE" {
+				t.Errorf("Locate = %+v, want Berlin, DE", location)
+			}
+		})
+	}
+}
+
+func TestProviderChainSkipsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client()}
+	failing, err := client.MappedProvider("failing", server.URL+"/{ip}", map[string]string{"city": "city"})
+	if err != nil {
+		t.Fatalf("MappedProvider: %v", err)
+	}
+	client.Providers = []Provider{failing, TestProvider("fallback", &LocationDetails{City: "Oslo"})}
+
+	info := &DomainInfo{CleanDomain: "example.com", IPAddress: "192.0.2.1"}
+	if err := client.Enrich(context.Background(), info); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if info.Location.City != "Oslo" {
+		t.Errorf("Location.City = %q, want Oslo from the fallback provider", info.Location.City)
+	}
+}
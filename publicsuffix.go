@@ -0,0 +1,286 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// bundledSuffixList is a snapshot of the most common multi-label rules from
+// the Public Suffix List (https://publicsuffix.org). Single-label TLDs are
+// covered by the implicit "*" rule and do not need to be listed.
+const bundledSuffixList = `
+ac.uk
+co.uk
+gov.uk
+ltd.uk
+me.uk
+net.uk
+nhs.uk
+org.uk
+plc.uk
+police.uk
+sch.uk
+asn.au
+com.au
+edu.au
+gov.au
+id.au
+net.au
+org.au
+ac.nz
+co.nz
+geek.nz
+govt.nz
+net.nz
+org.nz
+school.nz
+ac.jp
+co.jp
+go.jp
+ne.jp
+or.jp
+com.br
+edu.br
+gov.br
+net.br
+org.br
+com.cn
+edu.cn
+gov.cn
+net.cn
+org.cn
+ac.in
+co.in
+firm.in
+gen.in
+gov.in
+ind.in
+net.in
+org.in
+ac.za
+co.za
+gov.za
+org.za
+com.mx
+gob.mx
+org.mx
+com.ar
+gob.ar
+com.tr
+gov.tr
+co.kr
+go.kr
+or.kr
+com.tw
+gov.tw
+com.hk
+gov.hk
+com.sg
+gov.sg
+ac.il
+co.il
+org.il
+com.my
+gov.my
+com.ph
+com.vn
+com.pk
+com.ng
+co.ke
+com.eg
+com.sa
+com.ua
+ac.id
+co.id
+or.id
+com.es
+com.pl
+co.th
+in.th
+com.co
+com.pe
+com.ve
+*.ck
+!www.ck
+*.bd
+*.np
+appspot.com
+azurewebsites.net
+blogspot.com
+cloudfront.net
+elasticbeanstalk.com
+github.io
+gitlab.io
+herokuapp.com
+netlify.app
+pages.dev
+s3.amazonaws.com
+vercel.app
+workers.dev
+`
+
+type suffixList struct {
+	rules      map[string]bool
+	wildcards  map[string]bool
+	exceptions map[string]bool
+}
+
+func parseSuffixList(data string) *suffixList {
+	list := &suffixList{
+		rules:      make(map[string]bool),
+		wildcards:  make(map[string]bool),
+		exceptions: make(map[string]bool),
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.ToLower(line)
+
+		switch {
+		case strings.HasPrefix(line, "!"):
+			list.exceptions[line[1:]] = true
+		case strings.HasPrefix(line, "*."):
+			list.wildcards[line[2:]] = true
+		default:
+			list.rules[line] = true
+		}
+	}
+
+	return list
+}
+
+// PublicSuffixListURL is where RefreshPublicSuffixes fetches the full
+// Public Suffix List.
+var PublicSuffixListURL = "https://publicsuffix.org/list/public_suffix_list.dat"
+
+var publicSuffixes atomic.Pointer[suffixList]
+
+func init() {
+	publicSuffixes.Store(parseSuffixList(bundledSuffixList))
+}
+
+func RefreshPublicSuffixes(ctx context.Context) error {
+	return defaultClient.RefreshPublicSuffixes(ctx)
+}
+
+// RefreshPublicSuffixes replaces the bundled suffix snapshot with the full
+// list published at PublicSuffixListURL. The previous list is kept if the
+// download fails or does not look like the Public Suffix List.
+func (c *Client) RefreshPublicSuffixes(ctx context.Context) error {
+	body, err := c.fetch(ctx, "", PublicSuffixListURL)
+	if err != nil {
+		return fmt.Errorf("fetching public suffix list: %v", err)
+	}
+
+	list := parseSuffixList(string(body))
+	if !list.rules["com"] {
+		return fmt.Errorf("public suffix list has no rule for com")
+	}
+	publicSuffixes.Store(list)
+	return nil
+}
+
+// PublicSuffix returns the public suffix (effective TLD) of domain, such as
+// "com" for "www.example.com" or "co.uk" for "example.co.uk".
+func PublicSuffix(domain string) string {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	labels := strings.Split(domain, ".")
+	list := publicSuffixes.Load()
+
+	suffix := labels[len(labels)-1]
+	for i := len(labels) - 1; i >= 0; i-- {
+		candidate := strings.Join(labels[i:], ".")
+		if list.exceptions[candidate] {
+			return strings.Join(labels[i+1:], ".")
+		}
+		if list.rules[candidate] {
+			suffix = candidate
+		}
+		if i > 0 && list.wildcards[candidate] {
+			suffix = strings.Join(labels[i-1:], ".")
+		}
+	}
+
+	return suffix
+}
+
+// RegistrableDomain returns the public suffix plus one label (eTLD+1), such
+// as "example.co.uk" for "www.example.co.uk".
+func RegistrableDomain(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	suffix := PublicSuffix(domain)
+	if domain == suffix {
+		return "", fmt.Errorf("%s is a public suffix", domain)
+	}
+
+	rest := strings.TrimSuffix(domain, "."+suffix)
+	if i := strings.LastIndex(rest, "."); i >= 0 {
+		rest = rest[i+1:]
+	}
+	return rest + "." + suffix, nil
+}
+
+// registrableOrDomain is RegistrableDomain, falling back to domain itself
+// when it has no registrable domain.
+func registrableOrDomain(domain string) string {
+	if apex, err := RegistrableDomain(domain); err == nil {
+		return apex
+	}
+	return domain
+}
+
+// GroupByRegistrableDomain maps the registrable domain (eTLD+1) of each
+// input to the inputs under it, in input order, for rollups and
+// deduplication before any network work. Inputs are cleaned like Resolve
+// cleans them, without lookups. Inputs with no registrable domain, such as
+// IP addresses and bare public suffixes, are grouped under "".
+func GroupByRegistrableDomain(inputs []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, input := range inputs {
+		domain := canonicalizeDomain(cleanDomainInput(input))
+		if !isASCII(domain) {
+			domain = ToASCII(domain)
+		}
+
+		apex := ""
+		if net.ParseIP(domain) == nil && domain != "" {
+			apex, _ = RegistrableDomain(domain)
+		}
+		groups[apex] = append(groups[apex], input)
+	}
+	return groups
+}
+
+func (c *Client) checkTLDPolicy(domain string) error {
+	if len(c.AllowedTLDs) == 0 && len(c.BlockedTLDs) == 0 {
+		return nil
+	}
+
+	suffix := PublicSuffix(domain)
+	if matchesTLD(suffix, c.BlockedTLDs) {
+		return fmt.Errorf("%w: %s", ErrTLDNotAllowed, suffix)
+	}
+	if len(c.AllowedTLDs) > 0 && !matchesTLD(suffix, c.AllowedTLDs) {
+		return fmt.Errorf("%w: %s", ErrTLDNotAllowed, suffix)
+	}
+	return nil
+}
+
+func matchesTLD(suffix string, tlds []string) bool {
+	for _, tld := range tlds {
+		tld = strings.ToLower(strings.Trim(tld, ". "))
+		if suffix == tld || strings.HasSuffix(suffix, "."+tld) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package domaininfo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Punycode parameters from RFC 3492.
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+	acePrefix       = "xn--"
+)
+
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punyThreshold(k, bias int) int {
+	switch {
+	case k <= bias:
+		return punyTMin
+	case k >= bias+punyTMax:
+		return punyTMax
+	default:
+		return k - bias
+	}
+}
+
+func punyEncode(label string) string {
+	runes := []rune(label)
+	var out strings.Builder
+	for _, r := range runes {
+		if r < punyInitialN {
+			out.WriteRune(r)
+		}
+	}
+
+	basic := out.Len()
+	handled := basic
+	if basic > 0 {
+		out.WriteByte('-')
+	}
+
+	n, delta, bias := punyInitialN, 0, punyInitialBias
+	for handled < len(runes) {
+		m := int(utf8.MaxRune)
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+
+		delta += (m - n) * (handled + 1)
+		n = m
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) == n {
+				q := delta
+				for k := punyBase; ; k += punyBase {
+					t := punyThreshold(k, bias)
+					if q < t {
+						break
+					}
+					out.WriteByte(punyDigit(t + (q-t)%(punyBase-t)))
+					q = (q - t) / (punyBase - t)
+				}
+				out.WriteByte(punyDigit(q))
+				bias = punyAdapt(delta, handled+1, handled == basic)
+				delta = 0
+				handled++
+			}
+		}
+		delta++
+		n++
+	}
+
+	return out.String()
+}
+
+func punyDecode(encoded string) (string, error) {
+	var output []rune
+	pos := 0
+	if i := strings.LastIndexByte(encoded, '-'); i >= 0 {
+		for _, r := range encoded[:i] {
+			if r >= punyInitialN {
+				return "", fmt.Errorf("invalid punycode %q", encoded)
+			}
+			output = append(output, r)
+		}
+		pos = i + 1
+	}
+
+	n, i, bias := punyInitialN, 0, punyInitialBias
+	for pos < len(encoded) {
+		oldI, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if pos >= len(encoded) {
+				return "", fmt.Errorf("invalid punycode %q", encoded)
+			}
+			c := encoded[pos]
+			pos++
+
+			var digit int
+			switch {
+			case c >= 'a' && c <= 'z':
+				digit = int(c - 'a')
+			case c >= 'A' && c <= 'Z':
+				digit = int(c - 'A')
+			case c >= '0' && c <= '9':
+				digit = int(c-'0') + 26
+			default:
+				return "", fmt.Errorf("invalid punycode %q", encoded)
+			}
+
+			// Overflow checks from RFC 3492 section 6.2: a wrapped i
+			// would index outside output.
+			if digit > (math.MaxInt-i)/w {
+				return "", fmt.Errorf("invalid punycode %q: overflow", encoded)
+			}
+			i += digit * w
+			t := punyThreshold(k, bias)
+			if digit < t {
+				break
+			}
+			if w > math.MaxInt/(punyBase-t) {
+				return "", fmt.Errorf("invalid punycode %q: overflow", encoded)
+			}
+			w *= punyBase - t
+		}
+
+		bias = punyAdapt(i-oldI, len(output)+1, oldI == 0)
+		if i/(len(output)+1) > utf8.MaxRune-n {
+			return "", fmt.Errorf("invalid punycode %q", encoded)
+		}
+		n += i / (len(output) + 1)
+		i %= len(output) + 1
+		if n >= 0xD800 && n <= 0xDFFF {
+			return "", fmt.Errorf("invalid punycode %q: surrogate", encoded)
+		}
+
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+
+	return string(output), nil
+}
+
+// ToASCII converts an internationalized domain name to its punycode
+// (xn--) form. ASCII labels are lowercased and left otherwise unchanged.
+func ToASCII(domain string) string {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		label = strings.ToLower(label)
+		if isASCII(label) {
+			labels[i] = label
+		} else {
+			labels[i] = acePrefix + punyEncode(label)
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// ToUnicode converts the punycode labels of domain back to Unicode. If any
+// label fails to decode, domain is returned unchanged.
+func ToUnicode(domain string) string {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(strings.ToLower(label), acePrefix) {
+			continue
+		}
+		decoded, err := punyDecode(label[len(acePrefix):])
+		if err != nil {
+			return domain
+		}
+		labels[i] = decoded
+	}
+	return strings.Join(labels, ".")
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
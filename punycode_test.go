@@ -0,0 +1,46 @@
+package domaininfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPunycodeRoundTrip(t *testing.T) {
+	for _, domain := range []string{"bücher.example", "münchen.de", "例え.jp", "example.com"} {
+		ascii := ToASCII(domain)
+		if !isASCII(ascii) {
+			t.Errorf("ToASCII(%q) = %q, want ASCII", domain, ascii)
+		}
+		if got := ToUnicode(ascii); got != domain {
+			t.Errorf("ToUnicode(%q) = %q, want %q", ascii, got, domain)
+		}
+	}
+}
+
+func TestPunycodeRejectsOverflow(t *testing.T) {
+	for _, label := range []string{"00a000000000000000000z", "99999999999999999999999a"} {
+		if decoded, err := punyDecode(label); err == nil {
+			t.Errorf("punyDecode(%q) = %q, want error", label, decoded)
+		}
+	}
+}
+
+func FuzzToUnicode(f *testing.F) {
+	for _, seed := range []string{
+		"xn--00a000000000000000000z.com",
+		"xn--bcher-kva.example",
+		"xn--.com",
+		"xn--zzzzzzzzzzzzzzzz",
+		"www.example.com",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, domain string) {
+		unicodeDomain := ToUnicode(domain)
+		IsHomograph(domain)
+		if strings.Count(unicodeDomain, ".") != strings.Count(domain, ".") {
+			t.Errorf("ToUnicode(%q) = %q changed the label count", domain, unicodeDomain)
+		}
+	})
+}
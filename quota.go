@@ -0,0 +1,46 @@
+package domaininfo
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+)
+
+var quotaHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining", "X-Quota-Remaining"}
+
+func ProviderQuota() map[string]int64 {
+	return defaultClient.ProviderQuota()
+}
+
+// ProviderQuota returns the remaining request quota last reported by each
+// provider. Providers that never sent a quota header are omitted.
+func (c *Client) ProviderQuota() map[string]int64 {
+	quotas := make(map[string]int64)
+	c.quotas.Range(func(key, value any) bool {
+		quotas[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return quotas
+}
+
+func (c *Client) recordQuota(provider string, header http.Header) {
+	if provider == "" {
+		return
+	}
+
+	for _, name := range quotaHeaders {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+
+		remaining, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		counter, _ := c.quotas.LoadOrStore(provider, new(atomic.Int64))
+		counter.(*atomic.Int64).Store(remaining)
+		return
+	}
+}
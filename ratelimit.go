@@ -0,0 +1,43 @@
+package domaininfo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type rateLimiter struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the next request may be made at perSecond requests per
+// second. A non-positive rate disables limiting.
+func (rl *rateLimiter) wait(ctx context.Context, perSecond float64) error {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	interval := time.Duration(float64(time.Second) / perSecond)
+	rl.mu.Lock()
+	now := time.Now()
+	if rl.next.Before(now) {
+		rl.next = now
+	}
+	delay := rl.next.Sub(now)
+	rl.next = rl.next.Add(interval)
+	rl.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,225 @@
+package domaininfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const rdapBootstrap = "https://rdap.org"
+
+// RDAPInfo holds the fields parsed from an RDAP domain response.
+type RDAPInfo struct {
+	Domain      string
+	Registrar   string
+	Created     time.Time
+	Updated     time.Time
+	Expires     time.Time
+	NameServers []string
+	Status      []string
+
+	// RegistrantCountry is the country code, or name when no code is given,
+	// from the registrant entity's address. RegistrantRedacted is set when
+	// the registry withheld it for privacy.
+	RegistrantCountry  string
+	RegistrantRedacted bool
+}
+
+// IsLocked reports whether the domain carries a client or server transfer
+// lock.
+func (r *RDAPInfo) IsLocked() bool {
+	return hasTransferLock(r.Status)
+}
+
+// IsPendingDeletion reports whether the domain is in pendingDelete or
+// redemptionPeriod.
+func (r *RDAPInfo) IsPendingDeletion() bool {
+	return hasPendingDeletion(r.Status)
+}
+
+type rdapEntity struct {
+	Roles      []string          `json:"roles"`
+	VCardArray []json.RawMessage `json:"vcardArray"`
+	Entities   []rdapEntity      `json:"entities"`
+	Remarks    []rdapRemark      `json:"remarks"`
+}
+
+type rdapRemark struct {
+	Title       string   `json:"title"`
+	Description []string `json:"description"`
+}
+
+type rdapDomain struct {
+	LDHName string   `json:"ldhName"`
+	Status  []string `json:"status"`
+	Events  []struct {
+		Action string `json:"eventAction"`
+		Date   string `json:"eventDate"`
+	} `json:"events"`
+	Nameservers []struct {
+		LDHName string `json:"ldhName"`
+	} `json:"nameservers"`
+	Entities []rdapEntity `json:"entities"`
+
+	// Redacted lists fields removed for privacy (RFC 9537).
+	Redacted []struct {
+		Name struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"name"`
+	} `json:"redacted"`
+}
+
+func LookupRDAP(domain string) (*RDAPInfo, error) {
+	return defaultClient.LookupRDAP(context.Background(), domain)
+}
+
+// LookupRDAP fetches the RDAP record for domain through the rdap.org
+// bootstrap service, which redirects to the authoritative registry.
+// Subdomains are looked up by their registrable domain.
+func (c *Client) LookupRDAP(ctx context.Context, domain string) (*RDAPInfo, error) {
+	domain = registrableOrDomain(cleanDomainInput(domain))
+
+	body, err := c.fetchRDAP(ctx, fmt.Sprintf("%s/domain/%s", rdapBootstrap, domain))
+	if err != nil {
+		return nil, fmt.Errorf("RDAP query failed: %w", err)
+	}
+
+	var record rdapDomain
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, err
+	}
+
+	info := &RDAPInfo{Domain: strings.ToLower(record.LDHName)}
+	if info.Domain == "" {
+		info.Domain = domain
+	}
+
+	for _, status := range record.Status {
+		info.Status = appendStatus(info.Status, status)
+	}
+
+	for _, event := range record.Events {
+		t, err := time.Parse(time.RFC3339, event.Date)
+		if err != nil {
+			continue
+		}
+		switch event.Action {
+		case "registration":
+			info.Created = t
+		case "last changed":
+			info.Updated = t
+		case "expiration":
+			info.Expires = t
+		}
+	}
+
+	for _, ns := range record.Nameservers {
+		info.NameServers = append(info.NameServers, strings.ToLower(strings.TrimSuffix(ns.LDHName, ".")))
+	}
+
+	for _, entity := range record.Entities {
+		if containsString(entity.Roles, "registrar") {
+			info.Registrar = entity.vcardText("fn")
+		}
+		if containsString(entity.Roles, "registrant") && info.RegistrantCountry == "" {
+			info.RegistrantCountry = entity.vcardCountry()
+		}
+	}
+
+	for _, redacted := range record.Redacted {
+		name := strings.ToLower(redacted.Name.Type + " " + redacted.Name.Description)
+		if strings.Contains(name, "registrant") && strings.Contains(name, "country") {
+			info.RegistrantRedacted = true
+		}
+	}
+	if isRedactedValue(info.RegistrantCountry) {
+		info.RegistrantCountry = ""
+		info.RegistrantRedacted = true
+	}
+
+	return info, nil
+}
+
+// fetchRDAP fetches an RDAP document under the WHOIS timeout and retry
+// settings, which suit slow registries better than the geolocation ones.
+func (c *Client) fetchRDAP(ctx context.Context, rawURL string) ([]byte, error) {
+	var body []byte
+	err := c.retryWith(ctx, c.whoisRetryPolicy(), IsRetryable, func() error {
+		qctx, cancel := c.whoisContext(ctx)
+		defer cancel()
+
+		var err error
+		body, err = c.fetch(qctx, "", rawURL)
+		return err
+	})
+	return body, err
+}
+
+// vcardText returns the first text value of the named jCard property.
+func (e rdapEntity) vcardText(name string) string {
+	for _, value := range e.vcardValues(name) {
+		if s, ok := value.(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// vcardValues returns the values of every jCard property called name.
+func (e rdapEntity) vcardValues(name string) []any {
+	if len(e.VCardArray) < 2 {
+		return nil
+	}
+
+	var properties [][]any
+	if err := json.Unmarshal(e.VCardArray[1], &properties); err != nil {
+		return nil
+	}
+
+	var values []any
+	for _, property := range properties {
+		if len(property) < 4 {
+			continue
+		}
+		if key, ok := property[0].(string); ok && strings.EqualFold(key, name) {
+			values = append(values, property[3])
+		}
+	}
+	return values
+}
+
+// vcardCountry returns the country of the entity's jCard address: the cc
+// parameter when present, otherwise the country name component.
+func (e rdapEntity) vcardCountry() string {
+	if len(e.VCardArray) < 2 {
+		return ""
+	}
+
+	var properties [][]any
+	if err := json.Unmarshal(e.VCardArray[1], &properties); err != nil {
+		return ""
+	}
+
+	for _, property := range properties {
+		if len(property) < 4 {
+			continue
+		}
+		if key, ok := property[0].(string); !ok || !strings.EqualFold(key, "adr") {
+			continue
+		}
+		if params, ok := property[1].(map[string]any); ok {
+			if cc, ok := params["cc"].(string); ok && cc != "" {
+				return cc
+			}
+		}
+		if parts, ok := property[3].([]any); ok && len(parts) == 7 {
+			if country, ok := parts[6].(string); ok && country != "" {
+				return country
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLookupRDAPUsesRegistrableDomain(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"ldhName":"EXAMPLE.CO.UK","status":["client transfer prohibited"]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: &http.Client{Transport: rewriteTransport{server}}}
+	info, err := client.LookupRDAP(context.Background(), "www.example.co.uk")
+	if err != nil {
+		t.Fatalf("LookupRDAP: %v", err)
+	}
+	if path != "/domain/example.co.uk" {
+		t.Errorf("request path = %q, want /domain/example.co.uk", path)
+	}
+	if info.Domain != "example.co.uk" || !info.IsLocked() {
+		t.Errorf("LookupRDAP = %+v, want a locked example.co.uk", info)
+	}
+}
+
+func TestLookupRDAPWrapsStatusError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := &Client{HTTPClient: &http.Client{Transport: rewriteTransport{server}}}
+	_, err := client.LookupRDAP(context.Background(), "example.com")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("LookupRDAP error = %v, want a wrapped 404 StatusError", err)
+	}
+}
@@ -0,0 +1,68 @@
+package domaininfo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func ValidateReader(ctx context.Context, r io.Reader, concurrency int) <-chan DomainResult {
+	return defaultClient.ValidateReader(ctx, r, concurrency)
+}
+
+// ValidateReader validates one domain per line of r, skipping blank lines
+// and lines starting with #, and emits the results in completion order like
+// ValidateStream. A non-positive concurrency uses Client.Concurrency. Lines
+// may be of any length. If reading r fails, a final result with an empty
+// Input carries the error.
+func (c *Client) ValidateReader(ctx context.Context, r io.Reader, concurrency int) <-chan DomainResult {
+	if concurrency <= 0 {
+		concurrency = c.concurrency()
+	}
+
+	inputs := make(chan string)
+	var readErr error
+	go func() {
+		defer close(inputs)
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadString('\n')
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				select {
+				case inputs <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = fmt.Errorf("reading domains: %w", err)
+				}
+				return
+			}
+		}
+	}()
+
+	results := make(chan DomainResult)
+	go func() {
+		defer close(results)
+		for result := range c.validateStream(ctx, inputs, concurrency) {
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+		// Unless cancelled, the stream only finishes after inputs is
+		// closed, so readErr is safe to read here.
+		if ctx.Err() == nil && readErr != nil {
+			select {
+			case results <- DomainResult{Err: readErr}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
@@ -0,0 +1,35 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+)
+
+func RefreshData(ctx context.Context) error {
+	return defaultClient.RefreshData(ctx)
+}
+
+// RefreshData updates the bundled data tables from their canonical
+// sources: the Public Suffix List, the cloud provider ranges in
+// CloudRangeURLs and Cloudflare's ranges. Each table is swapped atomically
+// and keeps its previous contents when its download fails, so it is safe to
+// call periodically from a long-running service while lookups are in
+// flight. Failures are joined into the returned error.
+//
+// WHOIS servers for TLDs missing from the bundled map are discovered
+// through IANA on demand; RefreshData also forgets the servers the client
+// has discovered so far, so moved servers are picked up again.
+func (c *Client) RefreshData(ctx context.Context) error {
+	errs := []error{
+		c.RefreshPublicSuffixes(ctx),
+		c.RefreshCloudRanges(ctx),
+		c.RefreshCloudflareRanges(ctx),
+	}
+
+	c.whoisDiscovered.Range(func(key, _ any) bool {
+		c.whoisDiscovered.Delete(key)
+		return true
+	})
+
+	return errors.Join(errs...)
+}
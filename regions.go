@@ -0,0 +1,78 @@
+package domaininfo
+
+// CloudRegion is a cloud provider region and the approximate coordinates
+// of its datacenters.
+type CloudRegion struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+// CloudRegions is the table consulted by NearestRegion. It can be edited or
+// replaced to add providers or regions.
+var CloudRegions = []CloudRegion{
+	{"aws:us-east-1", 38.95, -77.45},
+	{"aws:us-east-2", 39.96, -83.00},
+	{"aws:us-west-1", 37.35, -121.96},
+	{"aws:us-west-2", 45.84, -119.70},
+	{"aws:ca-central-1", 45.50, -73.57},
+	{"aws:sa-east-1", -23.55, -46.63},
+	{"aws:eu-west-1", 53.35, -6.26},
+	{"aws:eu-west-2", 51.51, -0.13},
+	{"aws:eu-west-3", 48.86, 2.35},
+	{"aws:eu-central-1", 50.11, 8.68},
+	{"aws:eu-north-1", 59.33, 18.07},
+	{"aws:eu-south-1", 45.46, 9.19},
+	{"aws:me-south-1", 26.07, 50.56},
+	{"aws:af-south-1", -33.92, 18.42},
+	{"aws:ap-south-1", 19.08, 72.88},
+	{"aws:ap-southeast-1", 1.35, 103.82},
+	{"aws:ap-southeast-2", -33.87, 151.21},
+	{"aws:ap-northeast-1", 35.68, 139.69},
+	{"aws:ap-northeast-2", 37.57, 126.98},
+	{"aws:ap-northeast-3", 34.69, 135.50},
+	{"aws:ap-east-1", 22.32, 114.17},
+	{"gcp:us-central1", 41.26, -95.86},
+	{"gcp:us-east1", 33.20, -80.01},
+	{"gcp:us-east4", 39.03, -77.49},
+	{"gcp:us-west1", 45.60, -121.18},
+	{"gcp:us-west2", 34.05, -118.24},
+	{"gcp:northamerica-northeast1", 45.50, -73.57},
+	{"gcp:southamerica-east1", -23.55, -46.63},
+	{"gcp:europe-west1", 50.45, 3.82},
+	{"gcp:europe-west2", 51.51, -0.13},
+	{"gcp:europe-west3", 50.11, 8.68},
+	{"gcp:europe-west4", 53.44, 6.84},
+	{"gcp:europe-north1", 60.57, 27.19},
+	{"gcp:asia-south1", 19.08, 72.88},
+	{"gcp:asia-southeast1", 1.35, 103.82},
+	{"gcp:asia-east1", 24.05, 120.52},
+	{"gcp:asia-east2", 22.32, 114.17},
+	{"gcp:asia-northeast1", 35.68, 139.69},
+	{"gcp:asia-northeast3", 37.57, 126.98},
+	{"gcp:australia-southeast1", -33.87, 151.21},
+	{"gcp:me-west1", 32.09, 34.78},
+}
+
+// NearestRegion returns the entry of CloudRegions closest to the
+// coordinates in l and its distance in kilometres. It returns an empty name
+// when l is nil or has no coordinates.
+func NearestRegion(l *LocationDetails) (string, float64) {
+	if l == nil || (l.Latitude == 0 && l.Longitude == 0) {
+		return "", 0
+	}
+
+	var nearest string
+	best := -1.0
+	for _, region := range CloudRegions {
+		distance := haversineKm(l.Latitude, l.Longitude, region.Latitude, region.Longitude)
+		if best < 0 || distance < best {
+			nearest, best = region.Name, distance
+		}
+	}
+
+	if best < 0 {
+		return "", 0
+	}
+	return nearest, best
+}
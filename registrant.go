@@ -0,0 +1,75 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// redactionMarkers are phrases registries and registrars publish in place
+// of personal data withheld for privacy.
+var redactionMarkers = []string{
+	"redacted", "privacy", "not disclosed", "withheld", "data protected",
+	"gdpr", "statutory masking", "non-public",
+}
+
+func isRedactedValue(value string) bool {
+	value = strings.ToLower(value)
+	for _, marker := range redactionMarkers {
+		if strings.Contains(value, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func RegistrantCountry(domain string) (string, error) {
+	return defaultClient.RegistrantCountry(context.Background(), domain)
+}
+
+// RegistrantCountry returns the registrant's country for the registrable
+// domain of domain, preferring the RDAP registrant entity and falling back
+// to WHOIS. Two-letter codes are returned uppercased; registries that only
+// publish a name return the name. It returns ErrRedacted when the country
+// is withheld for privacy.
+func (c *Client) RegistrantCountry(ctx context.Context, domain string) (string, error) {
+	domain = c.normalizeDomain(domain)
+	apex, err := RegistrableDomain(domain)
+	if err != nil {
+		apex = domain
+	}
+
+	redacted := false
+	rdap, rdapErr := c.LookupRDAP(ctx, apex)
+	if rdapErr == nil {
+		if rdap.RegistrantCountry != "" {
+			return normalizeCountry(rdap.RegistrantCountry), nil
+		}
+		redacted = rdap.RegistrantRedacted
+	}
+
+	whois, whoisErr := c.LookupWHOIS(ctx, apex)
+	if whoisErr == nil {
+		if whois.RegistrantCountry != "" {
+			return normalizeCountry(whois.RegistrantCountry), nil
+		}
+		redacted = redacted || whois.RegistrantRedacted
+	}
+
+	if redacted {
+		return "", ErrRedacted
+	}
+	if err := errors.Join(rdapErr, whoisErr); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no registrant country published for %s", apex)
+}
+
+func normalizeCountry(country string) string {
+	country = strings.TrimSpace(country)
+	if len(country) == 2 {
+		return strings.ToUpper(country)
+	}
+	return country
+}
@@ -0,0 +1,65 @@
+package domaininfo
+
+import "strings"
+
+// RegistrationMismatch compares where a domain is hosted with where its
+// registrant is, a weak fraud signal when the two are far apart.
+type RegistrationMismatch struct {
+	HostingCountry    string
+	RegistrantCountry string
+	Mismatch          bool
+
+	// DistanceKm is the distance from the hosting location to the centroid
+	// of the registrant country. It is zero when either side cannot be
+	// placed on the map.
+	DistanceKm float64
+}
+
+// CompareRegistration reports whether the geolocated hosting country differs
+// from registrantCountry, as parsed from WHOIS. Both ISO codes and country
+// names are accepted and converted to ISO codes before comparing; an
+// empty side never counts as a mismatch.
+func CompareRegistration(location *LocationDetails, registrantCountry string) RegistrationMismatch {
+	registrant := strings.TrimSpace(registrantCountry)
+	result := RegistrationMismatch{RegistrantCountry: registrant}
+	if location == nil {
+		return result
+	}
+
+	hosting := location.CountryCode
+	if hosting == "" {
+		hosting = location.Country
+	}
+	result.HostingCountry = hosting
+	if hosting == "" || registrant == "" {
+		return result
+	}
+
+	// Compare ISO codes when both sides can be converted, so "US" matches
+	// "United States", and fall back to the raw strings otherwise.
+	hostingCode := countryCode(location.CountryCode)
+	if hostingCode == "" {
+		hostingCode = countryCode(location.Country)
+	}
+	registrantCode := countryCode(registrant)
+	if hostingCode != "" && registrantCode != "" {
+		result.Mismatch = hostingCode != registrantCode
+	} else {
+		result.Mismatch = !strings.EqualFold(hosting, registrant) &&
+			!strings.EqualFold(location.Country, registrant)
+	}
+
+	regLat, regLong, ok := CountryCentroid(registrantCode)
+	if !ok {
+		return result
+	}
+
+	lat, long := location.Latitude, location.Longitude
+	if lat == 0 && long == 0 {
+		if lat, long, ok = CountryCentroid(hostingCode); !ok {
+			return result
+		}
+	}
+	result.DistanceKm = haversineKm(lat, long, regLat, regLong)
+	return result
+}
@@ -0,0 +1,34 @@
+package domaininfo
+
+import "testing"
+
+func TestCompareRegistration(t *testing.T) {
+	tests := []struct {
+		name       string
+		location   *LocationDetails
+		registrant string
+		mismatch   bool
+	}{
+		{name: "same code", location: &LocationDetails{CountryCode: "US"}, registrant: "us"},
+		{name: "code and name", location: &LocationDetails{CountryCode: "US"}, registrant: "United States"},
+		{name: "name and code", location: &LocationDetails{Country: "Germany"}, registrant: "DE"},
+		{name: "alias", location: &LocationDetails{CountryCode: "GB"}, registrant: "UK"},
+		{name: "different", location: &LocationDetails{CountryCode: "US"}, registrant: "Germany", mismatch: true},
+		{name: "unknown name", location: &LocationDetails{Country: "Atlantis"}, registrant: "Atlantis"},
+		{name: "empty registrant", location: &LocationDetails{CountryCode: "US"}},
+		{name: "no location", registrant: "US"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CompareRegistration(tt.location, tt.registrant)
+			if result.Mismatch != tt.mismatch {
+				t.Errorf("Mismatch = %v, want %v", result.Mismatch, tt.mismatch)
+			}
+		})
+	}
+
+	if result := CompareRegistration(&LocationDetails{CountryCode: "US"}, "Germany"); result.DistanceKm == 0 {
+		t.Error("DistanceKm = 0 for a registrant country given by name, want the centroid distance")
+	}
+}
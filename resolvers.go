@@ -0,0 +1,166 @@
+package domaininfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	systemResolver   = "system"
+	overrideResolver = "override"
+)
+
+// resolutionMethod classifies the resolver reported by lookupIP, or the
+// "literal" and "supplied" markers, as a DomainInfo.ResolutionMethod.
+func (c *Client) resolutionMethod(resolvedBy string) string {
+	switch {
+	case resolvedBy == "":
+		return ""
+	case resolvedBy == systemResolver, resolvedBy == overrideResolver, resolvedBy == fixtureResolver,
+		resolvedBy == "literal", resolvedBy == "supplied":
+		return resolvedBy
+	case c.DoHURL != "" && resolvedBy == c.DoHURL:
+		return "doh"
+	}
+	return "resolver"
+}
+
+func ResolveIPs(domain string) ([]net.IP, error) {
+	return defaultClient.ResolveIPs(context.Background(), domain)
+}
+
+// ResolveIPs cleans and validates domain like Resolve and returns all of its
+// A and AAAA addresses as net.IP values, for callers doing their own CIDR
+// or address-family checks. An IP-literal input is returned as is.
+func (c *Client) ResolveIPs(ctx context.Context, domain string) ([]net.IP, error) {
+	clean := c.normalizeDomain(domain)
+	if ip := net.ParseIP(clean); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	if err := c.checkDomain(clean); err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	err := c.retry(ctx, isTransientDNSError, func() error {
+		var err error
+		ips, _, err = c.lookupIP(ctx, clean)
+		return err
+	})
+	if err != nil {
+		return nil, classifyDNSError(err)
+	}
+	return ips, nil
+}
+
+// lookupIP resolves domain through HostOverrides, DoH, the configured
+// Resolvers or the system resolver, in that order of preference, and
+// reports which one answered.
+func (c *Client) lookupIP(ctx context.Context, domain string) ([]net.IP, string, error) {
+	if override, ok := c.hostOverride(domain); ok {
+		ip := net.ParseIP(override)
+		if ip == nil {
+			return nil, overrideResolver, fmt.Errorf("invalid override IP %q for %s", override, domain)
+		}
+		return []net.IP{ip}, overrideResolver, nil
+	}
+
+	if c.DoHURL != "" {
+		ips, err := c.lookupIPDoH(ctx, domain)
+		return ips, c.DoHURL, err
+	}
+
+	var ips []net.IP
+	resolvedBy, err := c.tryResolvers(ctx, func(resolver *net.Resolver) (err error) {
+		ips, err = resolver.LookupIP(ctx, "ip", domain)
+		return err
+	})
+	if err != nil {
+		return nil, resolvedBy, err
+	}
+	return ips, resolvedBy, nil
+}
+
+// lookupMX resolves the MX records of domain through the same sources as
+// lookupIP. A domain in HostOverrides has no records to ask for, so it is
+// its own mail host, as with the implicit MX of RFC 5321.
+func (c *Client) lookupMX(ctx context.Context, domain string) ([]*net.MX, string, error) {
+	if _, ok := c.hostOverride(domain); ok {
+		return []*net.MX{{Host: domain + ".", Pref: 0}}, overrideResolver, nil
+	}
+
+	if c.DoHURL != "" {
+		mx, err := c.lookupMXDoH(ctx, domain)
+		return mx, c.DoHURL, err
+	}
+
+	var mx []*net.MX
+	resolvedBy, err := c.tryResolvers(ctx, func(resolver *net.Resolver) (err error) {
+		mx, err = resolver.LookupMX(ctx, domain)
+		return err
+	})
+	if err != nil {
+		return nil, resolvedBy, err
+	}
+	return mx, resolvedBy, nil
+}
+
+// tryResolvers runs lookup against each of Client.Resolvers in turn until
+// one answers or reports that the name does not exist, or against the
+// system resolver when none are configured. It reports which one answered.
+func (c *Client) tryResolvers(ctx context.Context, lookup func(*net.Resolver) error) (string, error) {
+	if len(c.Resolvers) == 0 {
+		return systemResolver, lookup(c.resolver())
+	}
+
+	var lastErr error
+	for _, server := range c.Resolvers {
+		server = resolverAddress(server)
+		err := lookup(c.resolverFor(server))
+		if err == nil {
+			return server, nil
+		}
+
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return server, err
+		}
+		if ctx.Err() != nil {
+			return server, err
+		}
+		lastErr = err
+	}
+
+	return "", lastErr
+}
+
+func (c *Client) hostOverride(domain string) (string, bool) {
+	if len(c.HostOverrides) == 0 {
+		return "", false
+	}
+	if ip, ok := c.HostOverrides[domain]; ok {
+		return ip, true
+	}
+	ip, ok := c.HostOverrides[strings.ToLower(strings.TrimSuffix(domain, "."))]
+	return ip, ok
+}
+
+func (c *Client) resolverFor(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return c.dialContext(ctx, network, server)
+		},
+	}
+}
+
+func resolverAddress(server string) string {
+	server = strings.TrimSpace(server)
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), "53")
+}
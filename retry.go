@@ -0,0 +1,149 @@
+package domaininfo
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const defaultBudgetRatio = 0.1
+
+// JitterStrategy selects how retry delays are randomized, so that clients
+// failing at the same moment, as in a batch during a provider outage, do
+// not retry in lockstep.
+type JitterStrategy int
+
+const (
+	// JitterFull waits a random time between zero and the exponential
+	// delay, which doubles from Backoff on each attempt.
+	JitterFull JitterStrategy = iota
+
+	// JitterDecorrelated waits a random time between Backoff and three
+	// times the previous wait.
+	JitterDecorrelated
+
+	// JitterNone waits exactly the exponential delay.
+	JitterNone
+)
+
+// RetryPolicy controls how transient failures are retried: DNS lookups,
+// geolocation provider requests and, unless Client.WHOISRetries overrides
+// it, WHOIS and RDAP queries. The zero value disables retries. Backoff is
+// the base delay before the first retry; it grows on each subsequent
+// attempt and is randomized according to Jitter. MaxBackoff, when
+// positive, caps every delay.
+//
+// BudgetTokens, when positive, caps retries across every lookup made by the
+// client, following gRPC's retry throttling: each retryable failure costs a
+// token, each success earns BudgetRatio tokens (0.1 by default), and no
+// retries are made while half or fewer of the tokens remain. This keeps a
+// large batch from turning a provider outage into a retry storm.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Jitter     JitterStrategy
+
+	BudgetTokens int
+	BudgetRatio  float64
+}
+
+// retryBudget is the token bucket shared by a client's retries.
+type retryBudget struct {
+	mu      sync.Mutex
+	started bool
+	tokens  float64
+}
+
+// record updates the budget with the outcome of an attempt and reports
+// whether a retry is allowed afterwards.
+func (b *retryBudget) record(policy RetryPolicy, failed bool) bool {
+	if policy.BudgetTokens <= 0 {
+		return true
+	}
+	limit := float64(policy.BudgetTokens)
+	ratio := policy.BudgetRatio
+	if ratio <= 0 {
+		ratio = defaultBudgetRatio
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.started {
+		b.tokens, b.started = limit, true
+	}
+	if failed {
+		b.tokens = max(b.tokens-1, 0)
+	} else {
+		b.tokens = min(b.tokens+ratio, limit)
+	}
+	return b.tokens > limit/2
+}
+
+func (c *Client) retry(ctx context.Context, retryable func(error) bool,
+	fn func() error) error {
+	return c.retryWith(ctx, c.Retry, retryable, fn)
+}
+
+// retryWith is retry under policy instead of Client.Retry, for protocols
+// with their own retry settings.
+func (c *Client) retryWith(ctx context.Context, policy RetryPolicy,
+	retryable func(error) bool, fn func() error) error {
+	err := fn()
+	delay, wait := policy.Backoff, policy.Backoff
+	for attempt := 0; attempt < policy.MaxRetries; attempt++ {
+		if err == nil || !retryable(err) {
+			break
+		}
+		if !c.retryBudget.record(policy, true) {
+			return err
+		}
+
+		wait = policy.nextWait(delay, wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		err = fn()
+		delay *= 2
+		if policy.MaxBackoff > 0 {
+			delay = min(delay, policy.MaxBackoff)
+		}
+	}
+
+	if err == nil {
+		c.retryBudget.record(policy, false)
+	} else if retryable(err) {
+		c.retryBudget.record(policy, true)
+	}
+	return err
+}
+
+// nextWait returns the wait before a retry given the current exponential
+// delay and the previous wait.
+func (p RetryPolicy) nextWait(delay, prev time.Duration) time.Duration {
+	var wait time.Duration
+	switch p.Jitter {
+	case JitterNone:
+		wait = delay
+	case JitterDecorrelated:
+		wait = p.Backoff
+		if spread := 3*prev - p.Backoff; spread > 0 {
+			wait += time.Duration(rand.Int63n(int64(spread)))
+		}
+	default:
+		if delay > 0 {
+			wait = time.Duration(rand.Int63n(int64(delay)))
+		}
+	}
+
+	if p.MaxBackoff > 0 {
+		wait = min(wait, p.MaxBackoff)
+	}
+	return wait
+}
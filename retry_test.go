@@ -0,0 +1,116 @@
+package domaininfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNextWaitBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		delay  time.Duration
+		prev   time.Duration
+		min    time.Duration
+		max    time.Duration
+	}{
+		{
+			name:   "full",
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond},
+			delay:  40 * time.Millisecond,
+			max:    40 * time.Millisecond,
+		},
+		{
+			name:   "decorrelated",
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond, Jitter: JitterDecorrelated},
+			prev:   20 * time.Millisecond,
+			min:    10 * time.Millisecond,
+			max:    60 * time.Millisecond,
+		},
+		{
+			name:   "none",
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond, Jitter: JitterNone},
+			delay:  40 * time.Millisecond,
+			min:    40 * time.Millisecond,
+			max:    40 * time.Millisecond,
+		},
+		{
+			name:   "capped",
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond, MaxBackoff: 15 * time.Millisecond, Jitter: JitterDecorrelated},
+			prev:   time.Second,
+			min:    10 * time.Millisecond,
+			max:    15 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				wait := tt.policy.nextWait(tt.delay, tt.prev)
+				if wait < tt.min || wait > tt.max {
+					t.Fatalf("nextWait = %v, want between %v and %v", wait, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderRetriesTransientFailures(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"city":"Berlin"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HTTPClient: server.Client(),
+		Retry: RetryPolicy{
+			MaxRetries: 2,
+			Backoff:    time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+			Jitter:     JitterDecorrelated,
+		},
+	}
+	provider, err := client.MappedProvider("flaky", server.URL+"/{ip}", map[string]string{"city": "city"})
+	if err != nil {
+		t.Fatalf("MappedProvider: %v", err)
+	}
+	client.Providers = []Provider{provider}
+
+	info := &DomainInfo{CleanDomain: "example.com", IPAddress: "192.0.2.1"}
+	if err := client.Enrich(context.Background(), info); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if info.Location.City != "Berlin" {
+		t.Errorf("Location.City = %q, want Berlin", info.Location.City)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("provider requests = %d, want 2", got)
+	}
+}
+
+func TestWHOISRetryPolicy(t *testing.T) {
+	tests := []struct {
+		retries int
+		want    int
+	}{
+		{retries: 0, want: 3},
+		{retries: 5, want: 5},
+		{retries: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		client := &Client{Retry: RetryPolicy{MaxRetries: 3}, WHOISRetries: tt.retries}
+		if got := client.whoisRetryPolicy().MaxRetries; got != tt.want {
+			t.Errorf("WHOISRetries %d: MaxRetries = %d, want %d", tt.retries, got, tt.want)
+		}
+	}
+}
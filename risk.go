@@ -0,0 +1,203 @@
+package domaininfo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RiskSignal names one input to RiskScore.
+type RiskSignal string
+
+const (
+	RiskNewlyRegistered RiskSignal = "newly_registered"
+	RiskDomainAge       RiskSignal = "domain_age"
+	RiskDNSBL           RiskSignal = "dnsbl"
+	RiskHomograph       RiskSignal = "homograph"
+	RiskNoTLS           RiskSignal = "no_tls"
+)
+
+// riskWeights are the points each signal adds when triggered. The total is
+// capped at 100.
+var riskWeights = map[RiskSignal]int{
+	RiskNewlyRegistered: 25,
+	RiskDomainAge:       10,
+	RiskDNSBL:           40,
+	RiskHomograph:       30,
+	RiskNoTLS:           15,
+}
+
+const (
+	newlyRegisteredWindow = 30 * 24 * time.Hour
+	youngDomainWindow     = 365 * 24 * time.Hour
+)
+
+// DomainBlocklists are the domain-based DNS blocklists consulted by the
+// RiskDNSBL signal.
+var DomainBlocklists = []string{"dbl.spamhaus.org", "multi.surbl.org"}
+
+// RiskContribution is the outcome of one signal. Err is set when the signal
+// could not be evaluated, in which case it contributes nothing.
+type RiskContribution struct {
+	Signal    RiskSignal
+	Triggered bool
+	Points    int
+	Detail    string
+	Err       error
+}
+
+// RiskReport is a composite 0–100 abuse score with the contribution of each
+// signal that was evaluated.
+type RiskReport struct {
+	Domain        string
+	Score         int
+	Contributions []RiskContribution
+}
+
+func RiskScore(domain string) (*RiskReport, error) {
+	return defaultClient.RiskScore(context.Background(), domain)
+}
+
+// RiskScore evaluates the signals in Client.RiskSignals, or all of them when
+// it is nil, concurrently and sums the points of those triggered. A signal
+// that cannot be evaluated is reported with its error and adds nothing; an
+// error is only returned for invalid input.
+func (c *Client) RiskScore(ctx context.Context, domain string) (*RiskReport, error) {
+	clean := c.normalizeDomain(domain)
+	if err := c.checkDomain(clean); err != nil {
+		return nil, err
+	}
+
+	signals := c.RiskSignals
+	if signals == nil {
+		signals = []RiskSignal{RiskNewlyRegistered, RiskDomainAge, RiskDNSBL, RiskHomograph, RiskNoTLS}
+	}
+	enabled := make(map[RiskSignal]bool, len(signals))
+	for _, signal := range signals {
+		enabled[signal] = true
+	}
+
+	report := &RiskReport{Domain: clean}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	add := func(contribution RiskContribution) {
+		if contribution.Triggered {
+			contribution.Points = riskWeights[contribution.Signal]
+		}
+		mu.Lock()
+		report.Contributions = append(report.Contributions, contribution)
+		mu.Unlock()
+	}
+	run := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
+	if enabled[RiskNewlyRegistered] || enabled[RiskDomainAge] {
+		run(func() {
+			created, err := c.creationDate(ctx, clean)
+			for _, signal := range []RiskSignal{RiskNewlyRegistered, RiskDomainAge} {
+				if !enabled[signal] {
+					continue
+				}
+				contribution := RiskContribution{Signal: signal, Err: err}
+				if err == nil {
+					age := time.Since(created)
+					window := youngDomainWindow
+					if signal == RiskNewlyRegistered {
+						window = newlyRegisteredWindow
+					}
+					contribution.Triggered = age < window
+					contribution.Detail = fmt.Sprintf("registered %s", created.Format("2006-01-02"))
+				}
+				add(contribution)
+			}
+		})
+	}
+
+	if enabled[RiskDNSBL] {
+		run(func() {
+			listed, err := c.blocklisted(ctx, clean)
+			contribution := RiskContribution{Signal: RiskDNSBL, Err: err, Triggered: listed != ""}
+			if listed != "" {
+				contribution.Detail = "listed on " + listed
+			}
+			add(contribution)
+		})
+	}
+
+	if enabled[RiskHomograph] {
+		run(func() {
+			homograph, skeleton := IsHomograph(clean)
+			contribution := RiskContribution{Signal: RiskHomograph, Triggered: homograph}
+			if homograph {
+				contribution.Detail = "resembles " + skeleton
+			}
+			add(contribution)
+		})
+	}
+
+	if enabled[RiskNoTLS] {
+		run(func() {
+			_, err := c.tlsHandshake(ctx, clean, &tls.Config{ServerName: clean})
+			contribution := RiskContribution{Signal: RiskNoTLS, Triggered: err != nil}
+			if err != nil {
+				contribution.Detail = err.Error()
+			}
+			add(contribution)
+		})
+	}
+
+	wg.Wait()
+
+	order := make(map[RiskSignal]int, len(signals))
+	for i, signal := range signals {
+		order[signal] = i
+	}
+	sort.Slice(report.Contributions, func(i, j int) bool {
+		return order[report.Contributions[i].Signal] < order[report.Contributions[j].Signal]
+	})
+	for _, contribution := range report.Contributions {
+		report.Score += contribution.Points
+	}
+	report.Score = min(report.Score, 100)
+	return report, nil
+}
+
+// blocklisted returns the first DomainBlocklists zone listing the
+// registrable domain of domain. Answers of 127.0.0.1 and 127.255.255.x are
+// the lists' codes for refused or malformed queries, not listings.
+func (c *Client) blocklisted(ctx context.Context, domain string) (string, error) {
+	apex, err := RegistrableDomain(domain)
+	if err != nil {
+		apex = domain
+	}
+
+	var lastErr error
+	for _, zone := range DomainBlocklists {
+		addrs, err := c.resolver().LookupHost(ctx, apex+"."+zone)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+				lastErr = err
+			}
+			continue
+		}
+		for _, addr := range addrs {
+			ip := net.ParseIP(addr).To4()
+			if ip == nil || ip[0] != 127 || ip.Equal(net.IPv4(127, 0, 0, 1)) || ip[1] == 255 {
+				continue
+			}
+			return zone, nil
+		}
+	}
+	return "", lastErr
+}
@@ -0,0 +1,54 @@
+package domaininfo
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+)
+
+// SNIReport compares the certificate a server presents for a domain's SNI
+// name with the default one it presents when no SNI is sent.
+type SNIReport struct {
+	Certificate        *x509.Certificate
+	DefaultCertificate *x509.Certificate
+
+	// SNIMismatch is set when the two certificates differ, meaning the
+	// server's default virtual host is a different site. Clients that omit
+	// SNI, such as some scanners and old libraries, see the wrong
+	// certificate.
+	SNIMismatch bool
+
+	// SNIRequired is set when the handshake without SNI fails, which is
+	// common and harmless for servers hosting many names.
+	SNIRequired bool
+}
+
+func CheckSNI(domain string) (*SNIReport, error) {
+	return defaultClient.CheckSNI(context.Background(), domain)
+}
+
+// CheckSNI connects to domain twice, once with its name as SNI and once
+// with no SNI, and reports whether the server falls back to a different
+// default certificate. An error is only returned when the handshake with
+// SNI fails.
+func (c *Client) CheckSNI(ctx context.Context, domain string) (*SNIReport, error) {
+	domain = ToASCII(cleanDomainInput(domain))
+	cert, err := c.leafCertificate(ctx, domain, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SNIReport{Certificate: cert}
+	defaultCert, err := c.leafCertificate(ctx, domain, "")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		report.SNIRequired = true
+		return report, nil
+	}
+
+	report.DefaultCertificate = defaultCert
+	report.SNIMismatch = !bytes.Equal(cert.Raw, defaultCert.Raw)
+	return report, nil
+}
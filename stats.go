@@ -0,0 +1,155 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// latencyWeight is the weight of the newest sample in the moving
+	// average.
+	latencyWeight = 0.2
+
+	// reliabilityInterval is how long a computed reliability order is
+	// reused before being recomputed from the latest stats.
+	reliabilityInterval = 30 * time.Second
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the latency
+// histogram kept for each provider.
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// providerStats are the outcomes observed for one provider by a client.
+type providerStats struct {
+	latency   atomic.Int64 // moving average, in nanoseconds
+	successes atomic.Int64
+	failures  atomic.Int64
+
+	// buckets counts attempts per latencyBuckets bound, non-cumulatively,
+	// with a final overflow bucket; totalNanos is their summed duration.
+	buckets    [9]atomic.Int64
+	totalNanos atomic.Int64
+}
+
+// successRate is the Laplace-smoothed success ratio, so a provider with no
+// history scores 0.5 rather than 0 or 1.
+func (s *providerStats) successRate() float64 {
+	successes, failures := s.successes.Load(), s.failures.Load()
+	return float64(successes+1) / float64(successes+failures+2)
+}
+
+// reliabilitySnapshot caches the success rates used by ReliabilityOrder.
+type reliabilitySnapshot struct {
+	mu       sync.Mutex
+	computed time.Time
+	rates    map[string]float64
+}
+
+func ProviderLatency() map[string]time.Duration {
+	return defaultClient.ProviderLatency()
+}
+
+// ProviderLatency returns the moving average of each provider's response
+// time as observed by the client. Providers never tried are omitted.
+func (c *Client) ProviderLatency() map[string]time.Duration {
+	latencies := make(map[string]time.Duration)
+	c.stats.Range(func(key, value any) bool {
+		latencies[key.(string)] = time.Duration(value.(*providerStats).latency.Load())
+		return true
+	})
+	return latencies
+}
+
+func ProviderSuccessRate() map[string]float64 {
+	return defaultClient.ProviderSuccessRate()
+}
+
+// ProviderSuccessRate returns the smoothed fraction of attempts each
+// provider answered successfully. Providers never tried are omitted.
+func (c *Client) ProviderSuccessRate() map[string]float64 {
+	rates := make(map[string]float64)
+	c.stats.Range(func(key, value any) bool {
+		rates[key.(string)] = value.(*providerStats).successRate()
+		return true
+	})
+	return rates
+}
+
+func (c *Client) providerStats(provider string) *providerStats {
+	value, _ := c.stats.LoadOrStore(provider, new(providerStats))
+	return value.(*providerStats)
+}
+
+func (c *Client) recordAttempt(provider string, d time.Duration, err error) {
+	stats := c.providerStats(provider)
+	if err != nil {
+		stats.failures.Add(1)
+	} else {
+		stats.successes.Add(1)
+	}
+	stats.totalNanos.Add(int64(d))
+	stats.buckets[sort.SearchFloat64s(latencyBuckets, d.Seconds())].Add(1)
+
+	for {
+		old := stats.latency.Load()
+		next := int64(d)
+		if old != 0 {
+			next = old + int64(latencyWeight*float64(int64(d)-old))
+		}
+		if stats.latency.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
+
+// fitsDeadline returns an error when DeadlineAware is set and the
+// provider's typical latency exceeds the time left before ctx's deadline.
+// Providers without latency history are always attempted.
+func (c *Client) fitsDeadline(ctx context.Context, provider string) error {
+	if !c.DeadlineAware {
+		return nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	value, ok := c.stats.Load(provider)
+	if !ok {
+		return nil
+	}
+
+	typical := time.Duration(value.(*providerStats).latency.Load())
+	if remaining := time.Until(deadline); typical > remaining {
+		return fmt.Errorf("skipped %s: typical latency %v exceeds remaining %v", provider, typical, remaining.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// orderByReliability returns providers sorted by descending success rate,
+// using a snapshot of the stats recomputed at most every
+// reliabilityInterval. Providers with no history score the neutral 0.5, and
+// ties keep the configured order.
+func (c *Client) orderByReliability(providers []Provider) []Provider {
+	c.reliability.mu.Lock()
+	if c.reliability.rates == nil || time.Since(c.reliability.computed) > reliabilityInterval {
+		c.reliability.rates = c.ProviderSuccessRate()
+		c.reliability.computed = time.Now()
+	}
+	rates := c.reliability.rates
+	c.reliability.mu.Unlock()
+
+	score := func(p Provider) float64 {
+		if rate, ok := rates[p.Name]; ok {
+			return rate
+		}
+		return 0.5
+	}
+
+	ordered := append([]Provider(nil), providers...)
+	sort.SliceStable(ordered, func(i, j int) bool { return score(ordered[i]) > score(ordered[j]) })
+	return ordered
+}
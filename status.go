@@ -0,0 +1,28 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func ResolveStatus(domain string) (rcode string, answers int, err error) {
+	return defaultClient.ResolveStatus(context.Background(), domain)
+}
+
+// ResolveStatus sends one recursive A query for domain to the client's
+// DNS server and returns the response code as a name, such as "NOERROR",
+// "NXDOMAIN", "SERVFAIL" or "REFUSED", with the number of answer records.
+// Unlike Resolve it does not fold the outcome into an error: err is only
+// set when no response arrived. It uses the built-in DNS wire client and
+// the first of Client.Resolvers, or the system nameserver.
+func (c *Client) ResolveStatus(ctx context.Context, domain string) (rcode string, answers int, err error) {
+	name := strings.ToLower(c.normalizeDomain(domain))
+
+	server := c.dnsServer()
+	resp, err := c.exchange(ctx, server, name, dnsTypeA, true)
+	if err != nil {
+		return "", 0, fmt.Errorf("querying %s: %w", server, err)
+	}
+	return rcodeName(resp.RCode), len(resp.Answers), nil
+}
@@ -0,0 +1,243 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ProviderStrategy selects how the provider chain is queried.
+type ProviderStrategy int
+
+const (
+	// StrategyFirst tries providers in order and returns the first
+	// success.
+	StrategyFirst ProviderStrategy = iota
+
+	// StrategyMerge queries every provider and merges their answers. Empty
+	// fields are filled from later providers, and coordinates are chosen
+	// by accuracy radius or averaged when providers agree.
+	StrategyMerge
+
+	// StrategyHedged starts providers in order, each one Client.HedgeDelay
+	// after the previous unless it has already failed, and returns the
+	// first success, cancelling the rest.
+	StrategyHedged
+)
+
+const (
+	defaultCoordinateAgreementKm = 100
+	defaultHedgeDelay            = 500 * time.Millisecond
+)
+
+func (c *Client) queryMerge(ctx context.Context, span Span, ip, host string) (*LocationDetails, error) {
+	providers := c.providers(ip)
+	results := make([]*LocationDetails, len(providers))
+	errs := make([]error, len(providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider Provider) {
+			defer wg.Done()
+			results[i], errs[i] = c.attemptProvider(ctx, provider, ip, host)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	var merged *LocationDetails
+	var withCoords []*LocationDetails
+	for i, location := range results {
+		if errs[i] != nil || location == nil {
+			continue
+		}
+		if merged == nil {
+			merged = location
+			span.SetAttribute("provider", providers[i].Name)
+		} else {
+			fillEmptyFields(merged, location)
+		}
+		if location.Latitude != 0 || location.Longitude != 0 {
+			withCoords = append(withCoords, location)
+		}
+	}
+
+	if merged == nil {
+		var lastErr error
+		for _, err := range errs {
+			if err != nil {
+				lastErr = err
+			}
+		}
+		return nil, providersFailed(lastErr)
+	}
+
+	c.mergeCoordinates(merged, withCoords)
+	return merged, nil
+}
+
+func (c *Client) queryHedged(ctx context.Context, span Span, ip, host string) (*LocationDetails, error) {
+	providers := c.providers(ip)
+	if len(providers) == 0 {
+		return nil, providersFailed(nil)
+	}
+
+	delay := c.HedgeDelay
+	if delay <= 0 {
+		delay = defaultHedgeDelay
+	}
+
+	hctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		provider string
+		location *LocationDetails
+		err      error
+	}
+	outcomes := make(chan outcome, len(providers))
+	launched := 0
+	launch := func() {
+		provider := providers[launched]
+		launched++
+		go func() {
+			location, err := c.attemptProvider(hctx, provider, ip, host)
+			outcomes <- outcome{provider.Name, location, err}
+		}()
+	}
+
+	launch()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var lastErr error
+	for finished := 0; finished < len(providers); {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			if launched < len(providers) {
+				launch()
+				timer.Reset(delay)
+			}
+		case result := <-outcomes:
+			finished++
+			if result.err == nil {
+				span.SetAttribute("provider", result.provider)
+				return result.location, nil
+			}
+			lastErr = result.err
+			// Nothing is in flight: hedge immediately rather than idle.
+			if launched == finished && launched < len(providers) {
+				launch()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(delay)
+			}
+		}
+	}
+
+	return nil, providersFailed(lastErr)
+}
+
+func fillEmptyFields(dst, src *LocationDetails) {
+	fill := func(d *string, s string) {
+		if *d == "" {
+			*d = s
+		}
+	}
+	fill(&dst.City, src.City)
+	fill(&dst.Region, src.Region)
+	fill(&dst.PostalCode, src.PostalCode)
+	fill(&dst.Country, src.Country)
+	fill(&dst.CountryCode, src.CountryCode)
+	fill(&dst.ContinentCode, src.ContinentCode)
+	fill(&dst.Continent, src.Continent)
+	fill(&dst.ASN, src.ASN)
+	fill(&dst.Org, src.Org)
+}
+
+// mergeCoordinates picks the coordinates with the smallest accuracy radius
+// when any provider reports one. Otherwise it averages the coordinates if
+// they all lie within Client.CoordinateAgreementKm of each other, and keeps
+// the first provider's coordinates with a warning if they do not.
+func (c *Client) mergeCoordinates(merged *LocationDetails, candidates []*LocationDetails) {
+	if len(candidates) == 0 {
+		return
+	}
+
+	var spread float64
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			d := haversineKm(candidates[i].Latitude, candidates[i].Longitude, candidates[j].Latitude, candidates[j].Longitude)
+			spread = max(spread, d)
+		}
+	}
+	merged.CoordinateDisagreementKm = spread
+
+	var best *LocationDetails
+	for _, candidate := range candidates {
+		if candidate.AccuracyRadiusKm > 0 && (best == nil || candidate.AccuracyRadiusKm < best.AccuracyRadiusKm) {
+			best = candidate
+		}
+	}
+	if best != nil {
+		merged.Latitude, merged.Longitude = best.Latitude, best.Longitude
+		merged.AccuracyRadiusKm = best.AccuracyRadiusKm
+		return
+	}
+
+	threshold := c.CoordinateAgreementKm
+	if threshold <= 0 {
+		threshold = defaultCoordinateAgreementKm
+	}
+
+	if spread > threshold {
+		merged.Latitude, merged.Longitude = candidates[0].Latitude, candidates[0].Longitude
+		merged.Warnings = append(merged.Warnings, fmt.Sprintf("providers disagree on coordinates by %.0f km", spread))
+		return
+	}
+
+	var lat, long float64
+	for _, candidate := range candidates {
+		lat += candidate.Latitude
+		long += candidate.Longitude
+	}
+	merged.Latitude = lat / float64(len(candidates))
+	merged.Longitude = long / float64(len(candidates))
+}
+
+func LocateAllProviders(ip string) map[string]*LocationDetails {
+	return defaultClient.LocateAllProviders(context.Background(), ip)
+}
+
+// LocateAllProviders queries every provider for ip concurrently and returns
+// each answer keyed by provider name. Providers that failed map to nil.
+func (c *Client) LocateAllProviders(ctx context.Context, ip string) map[string]*LocationDetails {
+	providers := c.providers(ip)
+	results := make(map[string]*LocationDetails, len(providers))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, provider := range providers {
+		wg.Add(1)
+		go func(provider Provider) {
+			defer wg.Done()
+			location, err := c.attemptProvider(ctx, provider, ip, "")
+			if err != nil {
+				location = nil
+			}
+			mu.Lock()
+			results[provider.Name] = location
+			mu.Unlock()
+		}(provider)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,84 @@
+package domaininfo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// DefaultTableColumns are the columns WriteTable renders when none are
+// given.
+var DefaultTableColumns = []string{"domain", "ip", "city", "country", "asn"}
+
+// tableColumns are the DomainInfo columns; any other column names a
+// LocationDetails field by its ToMap key.
+var tableColumns = map[string]func(*DomainInfo) string{
+	"domain":      func(info *DomainInfo) string { return info.CleanDomain },
+	"input":       func(info *DomainInfo) string { return info.OriginalInput },
+	"ip":          func(info *DomainInfo) string { return info.IPAddress },
+	"ips":         func(info *DomainInfo) string { return strings.Join(info.IPAddresses, ",") },
+	"resolved_by": func(info *DomainInfo) string { return info.ResolvedBy },
+	"country": func(info *DomainInfo) string {
+		if info.Location == nil {
+			return ""
+		}
+		if info.Location.Country != "" {
+			return info.Location.Country
+		}
+		return info.Location.CountryCode
+	},
+}
+
+// WriteTable renders infos as an aligned text table with a header row, for
+// interactive CLI output. columns selects and orders the columns, using
+// "domain", "input", "ip", "ips", "resolved_by", "country" or any key of
+// LocationDetails.ToMap such as "region" or "org"; DefaultTableColumns is
+// used when none are given. Cells are blank for nil infos and for infos
+// without a Location.
+func WriteTable(w io.Writer, infos []*DomainInfo, columns ...string) error {
+	if len(columns) == 0 {
+		columns = DefaultTableColumns
+	}
+
+	known := make(map[string]bool)
+	for _, name := range (LocationDetails{}).FieldNames() {
+		known[name] = true
+	}
+	known["eu"] = true
+	for _, column := range columns {
+		if _, ok := tableColumns[column]; !ok && !known[column] {
+			return fmt.Errorf("unknown table column %q", column)
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	row := make([]string, len(columns))
+	for _, info := range infos {
+		for i, column := range columns {
+			switch {
+			case info == nil:
+				row[i] = ""
+			case tableColumns[column] != nil:
+				row[i] = tableColumns[column](info)
+			default:
+				row[i] = locationColumn(info, column)
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func locationColumn(info *DomainInfo, key string) string {
+	if info.Location == nil {
+		return ""
+	}
+	return info.Location.ToMap(false)[key]
+}
@@ -0,0 +1,95 @@
+package domaininfo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+)
+
+// TakeoverFingerprint describes a hosting service whose abandoned resources
+// can be claimed by anyone, leaving CNAMEs pointing at them open to
+// subdomain takeover. A CNAME ending in one of CNAMESuffixes is dangling
+// when its target does not resolve and NXDomain is set, or when the host
+// serves a page containing Body.
+type TakeoverFingerprint struct {
+	Service       string
+	CNAMESuffixes []string
+	Body          string
+	NXDomain      bool
+}
+
+// TakeoverFingerprints is the list CheckDanglingCNAME matches against. It
+// follows the fingerprints published by the can-i-take-over-xyz project and
+// may be extended by callers.
+var TakeoverFingerprints = []TakeoverFingerprint{
+	{Service: "AWS S3", CNAMESuffixes: []string{".s3.amazonaws.com", ".s3-website.amazonaws.com"}, Body: "NoSuchBucket"},
+	{Service: "AWS Elastic Beanstalk", CNAMESuffixes: []string{".elasticbeanstalk.com"}, NXDomain: true},
+	{Service: "Azure", CNAMESuffixes: []string{".azurewebsites.net", ".cloudapp.net", ".cloudapp.azure.com", ".trafficmanager.net", ".blob.core.windows.net", ".azureedge.net", ".azure-api.net"}, NXDomain: true},
+	{Service: "GitHub Pages", CNAMESuffixes: []string{".github.io"}, Body: "There isn't a GitHub Pages site here."},
+	{Service: "Heroku", CNAMESuffixes: []string{".herokuapp.com", ".herokudns.com"}, Body: "No such app", NXDomain: true},
+	{Service: "Shopify", CNAMESuffixes: []string{".myshopify.com"}, Body: "Sorry, this shop is currently unavailable."},
+	{Service: "Fastly", CNAMESuffixes: []string{".fastly.net"}, Body: "Fastly error: unknown domain"},
+	{Service: "Pantheon", CNAMESuffixes: []string{".pantheonsite.io"}, Body: "The gods are wise"},
+	{Service: "Ghost", CNAMESuffixes: []string{".ghost.io"}, Body: "The thing you were looking for is no longer here"},
+	{Service: "Surge.sh", CNAMESuffixes: []string{".surge.sh"}, Body: "project not found"},
+	{Service: "Zendesk", CNAMESuffixes: []string{".zendesk.com"}, Body: "Help Center Closed"},
+}
+
+func CheckDanglingCNAME(domain string) (bool, string, error) {
+	return defaultClient.CheckDanglingCNAME(context.Background(), domain)
+}
+
+// CheckDanglingCNAME follows the CNAME chain of domain and reports whether
+// it points at an unclaimed resource of a service in TakeoverFingerprints,
+// returning that service. Domains without a CNAME, or whose CNAME matches
+// no fingerprint, are not reported.
+func (c *Client) CheckDanglingCNAME(ctx context.Context, domain string) (bool, string, error) {
+	domain = ToASCII(cleanDomainInput(domain))
+	chain, _, err := c.ResolveChain(ctx, domain)
+	if len(chain) == 0 {
+		return false, "", err
+	}
+
+	fingerprint, ok := matchTakeoverFingerprint(chain)
+	if !ok {
+		return false, "", nil
+	}
+
+	switch {
+	case errors.Is(err, ErrDomainNotFound):
+		if fingerprint.NXDomain {
+			return true, fingerprint.Service, nil
+		}
+		return false, "", nil
+	case err != nil:
+		return false, "", err
+	case fingerprint.Body == "":
+		return false, "", nil
+	}
+
+	// The service answers for the original name through the Host header,
+	// so fetch the domain itself rather than the CNAME target.
+	page, err := c.fetchHomepage(ctx, domain)
+	if err != nil {
+		return false, "", err
+	}
+	if bytes.Contains(page.Body, []byte(fingerprint.Body)) {
+		return true, fingerprint.Service, nil
+	}
+	return false, "", nil
+}
+
+func matchTakeoverFingerprint(chain []string) (TakeoverFingerprint, bool) {
+	for _, target := range chain {
+		target = strings.ToLower(strings.TrimSuffix(target, "."))
+		for _, fingerprint := range TakeoverFingerprints {
+			for _, suffix := range fingerprint.CNAMESuffixes {
+				if strings.HasSuffix(target, suffix) {
+					return fingerprint, true
+				}
+			}
+		}
+	}
+	return TakeoverFingerprint{}, false
+}
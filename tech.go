@@ -0,0 +1,85 @@
+package domaininfo
+
+import (
+	"context"
+	"regexp"
+)
+
+// TechSignature matches a technology by a response header or by the page
+// body. Header is matched against Pattern when set; otherwise Pattern is
+// matched against the HTML body.
+type TechSignature struct {
+	Name    string
+	Header  string
+	Pattern *regexp.Regexp
+}
+
+// TechSignatures is the table consulted by DetectTech. Append to it to
+// recognise additional technologies.
+var TechSignatures = []TechSignature{
+	{Name: "nginx", Header: "Server", Pattern: regexp.MustCompile(`(?i)nginx`)},
+	{Name: "Apache", Header: "Server", Pattern: regexp.MustCompile(`(?i)apache`)},
+	{Name: "Microsoft IIS", Header: "Server", Pattern: regexp.MustCompile(`(?i)microsoft-iis`)},
+	{Name: "LiteSpeed", Header: "Server", Pattern: regexp.MustCompile(`(?i)litespeed`)},
+	{Name: "Caddy", Header: "Server", Pattern: regexp.MustCompile(`(?i)caddy`)},
+	{Name: "Cloudflare", Header: "Server", Pattern: regexp.MustCompile(`(?i)cloudflare`)},
+	{Name: "Cloudflare", Header: "Cf-Ray", Pattern: regexp.MustCompile(`.`)},
+	{Name: "Amazon CloudFront", Header: "X-Amz-Cf-Id", Pattern: regexp.MustCompile(`.`)},
+	{Name: "Fastly", Header: "X-Served-By", Pattern: regexp.MustCompile(`(?i)cache-`)},
+	{Name: "Vercel", Header: "Server", Pattern: regexp.MustCompile(`(?i)vercel`)},
+	{Name: "Netlify", Header: "Server", Pattern: regexp.MustCompile(`(?i)netlify`)},
+	{Name: "PHP", Header: "X-Powered-By", Pattern: regexp.MustCompile(`(?i)php`)},
+	{Name: "ASP.NET", Header: "X-Powered-By", Pattern: regexp.MustCompile(`(?i)asp\.net`)},
+	{Name: "Express", Header: "X-Powered-By", Pattern: regexp.MustCompile(`(?i)express`)},
+	{Name: "Next.js", Header: "X-Powered-By", Pattern: regexp.MustCompile(`(?i)next\.js`)},
+	{Name: "Drupal", Header: "X-Generator", Pattern: regexp.MustCompile(`(?i)drupal`)},
+	{Name: "WordPress", Pattern: regexp.MustCompile(`(?i)/wp-content/|/wp-includes/|<meta name="generator" content="WordPress`)},
+	{Name: "Joomla", Pattern: regexp.MustCompile(`(?i)<meta name="generator" content="Joomla`)},
+	{Name: "Drupal", Pattern: regexp.MustCompile(`(?i)<meta name="generator" content="Drupal|/sites/default/files/`)},
+	{Name: "Shopify", Pattern: regexp.MustCompile(`(?i)cdn\.shopify\.com`)},
+	{Name: "Wix", Pattern: regexp.MustCompile(`(?i)static\.wixstatic\.com`)},
+	{Name: "Squarespace", Pattern: regexp.MustCompile(`(?i)static1\.squarespace\.com`)},
+	{Name: "Ghost", Pattern: regexp.MustCompile(`(?i)<meta name="generator" content="Ghost`)},
+	{Name: "Next.js", Pattern: regexp.MustCompile(`id="__NEXT_DATA__"`)},
+	{Name: "React", Pattern: regexp.MustCompile(`data-reactroot|data-reactid`)},
+}
+
+func DetectTech(domain string) ([]string, error) {
+	return defaultClient.DetectTech(context.Background(), domain)
+}
+
+// DetectTech fetches the homepage of domain and returns the technologies
+// matched by TechSignatures, without duplicates.
+func (c *Client) DetectTech(ctx context.Context, domain string) ([]string, error) {
+	page, err := c.fetchHomepage(ctx, cleanDomainInput(domain))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var techs []string
+	for _, sig := range TechSignatures {
+		if seen[sig.Name] || sig.Pattern == nil {
+			continue
+		}
+
+		var matched bool
+		if sig.Header != "" {
+			for _, value := range page.Header.Values(sig.Header) {
+				if sig.Pattern.MatchString(value) {
+					matched = true
+					break
+				}
+			}
+		} else {
+			matched = sig.Pattern.Match(page.Body)
+		}
+
+		if matched {
+			seen[sig.Name] = true
+			techs = append(techs, sig.Name)
+		}
+	}
+
+	return techs, nil
+}
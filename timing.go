@@ -0,0 +1,43 @@
+package domaininfo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Timing records how long each phase of a validation took. Providers holds
+// the duration of each provider attempt made by this call; it is empty when
+// the result came from the cache or from a lookup shared with a concurrent
+// call.
+type Timing struct {
+	DNS       time.Duration
+	IPLookup  time.Duration
+	Geo       time.Duration
+	Providers map[string]time.Duration
+
+	mu sync.Mutex
+}
+
+type timingKey struct{}
+
+func withTiming(ctx context.Context, t *Timing) context.Context {
+	if t == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, timingKey{}, t)
+}
+
+func recordProviderTiming(ctx context.Context, provider string, d time.Duration) {
+	t, ok := ctx.Value(timingKey{}).(*Timing)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Providers == nil {
+		t.Providers = make(map[string]time.Duration)
+	}
+	t.Providers[provider] += d
+}
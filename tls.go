@@ -0,0 +1,98 @@
+package domaininfo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+const defaultTLSTimeout = 10 * time.Second
+
+// tlsHandshake connects to domain on port 443 and completes a handshake
+// using config, returning the negotiated state. Certificates are not
+// verified, so callers can inspect whatever the server presents. The
+// handshake is bounded by Client.Timeout, or defaultTLSTimeout when
+// neither it nor ctx sets a deadline, so a silent server cannot stall it.
+func (c *Client) tlsHandshake(ctx context.Context, domain string, config *tls.Config) (tls.ConnectionState, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancelDefault context.CancelFunc
+		ctx, cancelDefault = context.WithTimeout(ctx, defaultTLSTimeout)
+		defer cancelDefault()
+	}
+
+	conn, err := c.dialContext(ctx, "tcp", net.JoinHostPort(domain, "443"))
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	config = config.Clone()
+	config.InsecureSkipVerify = true
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return tls.ConnectionState{}, err
+	}
+	return tlsConn.ConnectionState(), nil
+}
+
+// leafCertificate returns the certificate domain serves for the given SNI
+// name; an empty serverName sends no SNI.
+func (c *Client) leafCertificate(ctx context.Context, domain, serverName string) (*x509.Certificate, error) {
+	state, err := c.tlsHandshake(ctx, domain, &tls.Config{ServerName: serverName})
+	if err != nil {
+		return nil, err
+	}
+	if len(state.PeerCertificates) == 0 {
+		return nil, errors.New("server presented no certificate")
+	}
+	return state.PeerCertificates[0], nil
+}
+
+func CertMatchesDomain(domain string) (bool, []string, error) {
+	return defaultClient.CertMatchesDomain(context.Background(), domain)
+}
+
+// CertMatchesDomain reports whether the certificate served for domain covers
+// it, by its subject common name or one of its DNS SANs, and returns the
+// SANs. A wildcard name matches exactly one label.
+func (c *Client) CertMatchesDomain(ctx context.Context, domain string) (bool, []string, error) {
+	domain = ToASCII(cleanDomainInput(domain))
+	cert, err := c.leafCertificate(ctx, domain, domain)
+	if err != nil {
+		return false, nil, err
+	}
+	return certCovers(cert, domain), cert.DNSNames, nil
+}
+
+func certCovers(cert *x509.Certificate, domain string) bool {
+	if cert.Subject.CommonName != "" && matchCertName(cert.Subject.CommonName, domain) {
+		return true
+	}
+	for _, name := range cert.DNSNames {
+		if matchCertName(name, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchCertName(pattern, domain string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		label, rest, found := strings.Cut(domain, ".")
+		return found && label != "" && rest == suffix
+	}
+	return pattern == domain
+}
@@ -0,0 +1,73 @@
+package domaininfo
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+)
+
+// probedTLSVersions are the protocol versions TLSVersions tries, oldest
+// first. SSL 3.0 is not supported by crypto/tls.
+var probedTLSVersions = []uint16{tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13}
+
+func TLSVersions(domain string) (min, max uint16, err error) {
+	return defaultClient.TLSVersions(context.Background(), domain)
+}
+
+// TLSVersions probes which TLS versions domain negotiates on port 443, with
+// one handshake per version forcing both the minimum and maximum to it, and
+// returns the oldest and newest that succeeded as crypto/tls version
+// constants. A minimum below tls.VersionTLS12 flags a server still
+// accepting TLS 1.0 or 1.1. When no version succeeds the last handshake
+// error is returned.
+func (c *Client) TLSVersions(ctx context.Context, domain string) (min, max uint16, err error) {
+	domain = ToASCII(cleanDomainInput(domain))
+
+	supported := make([]bool, len(probedTLSVersions))
+	errs := make([]error, len(probedTLSVersions))
+	var wg sync.WaitGroup
+	for i, version := range probedTLSVersions {
+		wg.Add(1)
+		go func(i int, version uint16) {
+			defer wg.Done()
+			_, errs[i] = c.tlsHandshake(ctx, domain, &tls.Config{
+				ServerName: domain,
+				MinVersion: version,
+				MaxVersion: version,
+			})
+			supported[i] = errs[i] == nil
+		}(i, version)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+	for i, ok := range supported {
+		if !ok {
+			continue
+		}
+		if min == 0 {
+			min = probedTLSVersions[i]
+		}
+		max = probedTLSVersions[i]
+	}
+	if max == 0 {
+		return 0, 0, fmt.Errorf("no TLS version negotiated with %s: %w", domain, errs[len(errs)-1])
+	}
+	return min, max, nil
+}
+
+func SupportsTLS13(domain string) (bool, error) {
+	return defaultClient.SupportsTLS13(context.Background(), domain)
+}
+
+// SupportsTLS13 reports whether domain negotiates TLS 1.3.
+func (c *Client) SupportsTLS13(ctx context.Context, domain string) (bool, error) {
+	_, max, err := c.TLSVersions(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+	return max == tls.VersionTLS13, nil
+}
@@ -0,0 +1,47 @@
+package domaininfo
+
+import "context"
+
+// Tracer starts a span for each phase of a lookup. Its shape follows the
+// OpenTelemetry trace.Tracer, so an OTel tracer can be plugged in with a
+// thin adapter without this package depending on the OTel SDK.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is the subset of an OpenTelemetry span used by this package.
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, any) {}
+func (noopSpan) RecordError(error)        {}
+func (noopSpan) End()                     {}
+
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...any) (context.Context, Span) {
+	if c.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	ctx, span := c.Tracer.Start(ctx, name)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if key, ok := attrs[i].(string); ok {
+			span.SetAttribute(key, attrs[i+1])
+		}
+	}
+	return ctx, span
+}
+
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.SetAttribute("status", "error")
+		span.RecordError(err)
+	} else {
+		span.SetAttribute("status", "ok")
+	}
+	span.End()
+}
@@ -0,0 +1,77 @@
+package domaininfo
+
+import "strings"
+
+const defaultTyposquatThreshold = 2
+
+func DetectTyposquat(domain string, brands []string) (string, bool) {
+	return defaultClient.DetectTyposquat(domain, brands)
+}
+
+// DetectTyposquat compares the registrable name of domain, without its
+// public suffix, against each brand and returns the first brand within
+// Client.TyposquatThreshold edits. Confusable characters are folded to
+// ASCII first, so homographs are caught as well. An exact match is the
+// brand itself and is not reported.
+func (c *Client) DetectTyposquat(domain string, brands []string) (string, bool) {
+	threshold := c.TyposquatThreshold
+	if threshold <= 0 {
+		threshold = defaultTyposquatThreshold
+	}
+
+	_, skeleton := IsHomograph(domain)
+	name := registrableLabel(skeleton)
+	original := registrableLabel(strings.ToLower(ToUnicode(cleanDomainInput(domain))))
+	if name == "" {
+		return "", false
+	}
+
+	for _, brand := range brands {
+		brandName := registrableLabel(strings.ToLower(strings.TrimSpace(brand)))
+		if brandName == "" || original == brandName {
+			continue
+		}
+		if levenshtein(name, brandName) <= threshold {
+			return brand, true
+		}
+	}
+
+	return "", false
+}
+
+// registrableLabel returns the label directly left of the public suffix,
+// such as "example" for "www.example.co.uk". Bare names are returned as is.
+func registrableLabel(domain string) string {
+	if !strings.Contains(domain, ".") {
+		return domain
+	}
+
+	registrable, err := RegistrableDomain(domain)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(registrable, "."+PublicSuffix(registrable))
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,266 @@
+package domaininfo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const ianaWHOIS = "whois.iana.org"
+
+// WHOISInfo holds the fields parsed from a WHOIS response. Raw keeps the
+// full response text for fields that are not parsed.
+type WHOISInfo struct {
+	Domain      string
+	Server      string
+	Registrar   string
+	Created     time.Time
+	Updated     time.Time
+	Expires     time.Time
+	NameServers []string
+	Status      []string
+	Raw         string
+
+	// RegistrantCountry is the registrant's country as published, usually
+	// an ISO 3166-1 alpha-2 code. It is empty when redacted or absent, and
+	// RegistrantRedacted tells the two apart.
+	RegistrantCountry  string
+	RegistrantRedacted bool
+}
+
+// IsLocked reports whether the domain carries a client or server transfer
+// lock.
+func (w *WHOISInfo) IsLocked() bool {
+	return hasTransferLock(w.Status)
+}
+
+// IsPendingDeletion reports whether the domain is in pendingDelete or
+// redemptionPeriod.
+func (w *WHOISInfo) IsPendingDeletion() bool {
+	return hasPendingDeletion(w.Status)
+}
+
+func LookupWHOIS(domain string) (*WHOISInfo, error) {
+	return defaultClient.LookupWHOIS(context.Background(), domain)
+}
+
+// LookupWHOIS queries the WHOIS servers known for the TLD of domain in
+// turn, falling back to discovering the authoritative server through IANA,
+// and parses the first response. Subdomains are looked up by their
+// registrable domain, since registries only hold records for those.
+func (c *Client) LookupWHOIS(ctx context.Context, domain string) (*WHOISInfo, error) {
+	domain = registrableOrDomain(cleanDomainInput(domain))
+	tld, servers := c.whoisServers(domain)
+
+	var lastErr error
+	for _, server := range servers {
+		info, err := c.lookupWHOISAt(ctx, server, domain)
+		if err == nil {
+			return info, nil
+		}
+		lastErr = err
+	}
+
+	server, err := c.discoverWHOISServer(ctx, tld)
+	if err != nil {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, err
+	}
+	if containsString(servers, server) {
+		return nil, lastErr
+	}
+	return c.lookupWHOISAt(ctx, server, domain)
+}
+
+func (c *Client) lookupWHOISAt(ctx context.Context, server, domain string) (*WHOISInfo, error) {
+	raw, err := c.queryWHOIS(ctx, server, domain)
+	if err != nil {
+		return nil, fmt.Errorf("WHOIS query to %s failed: %w", server, err)
+	}
+
+	info := parseWHOIS(raw)
+	info.Domain = domain
+	info.Server = server
+	return info, nil
+}
+
+// queryWHOIS sends query to server, bounding each attempt by WHOISTimeout
+// and retrying transient failures up to WHOISRetries times.
+func (c *Client) queryWHOIS(ctx context.Context, server, query string) (string, error) {
+	var response string
+	err := c.retryWith(ctx, c.whoisRetryPolicy(), IsRetryable, func() error {
+		qctx, cancel := c.whoisContext(ctx)
+		defer cancel()
+
+		var err error
+		response, err = c.queryWHOISOnce(qctx, server, query)
+		return err
+	})
+	return response, err
+}
+
+// whoisContext applies Client.WHOISTimeout to one WHOIS or RDAP query.
+func (c *Client) whoisContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.WHOISTimeout > 0 {
+		return context.WithTimeout(ctx, c.WHOISTimeout)
+	}
+	return ctx, func() {}
+}
+
+// whoisRetryPolicy is Client.Retry with MaxRetries replaced by
+// WHOISRetries when that is set, or zero when WHOISRetries is negative.
+func (c *Client) whoisRetryPolicy() RetryPolicy {
+	policy := c.Retry
+	switch {
+	case c.WHOISRetries < 0:
+		policy.MaxRetries = 0
+	case c.WHOISRetries > 0:
+		policy.MaxRetries = c.WHOISRetries
+	}
+	return policy
+}
+
+func (c *Client) queryWHOISOnce(ctx context.Context, server, query string) (string, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "43")
+	}
+
+	conn, err := c.dialContext(ctx, "tcp", server)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, query+"\r\n"); err != nil {
+		return "", err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(conn, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func parseWHOIS(raw string) *WHOISInfo {
+	info := &WHOISInfo{Raw: raw}
+
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		key, value, ok := splitWHOISLine(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "registrar", "registrar name", "sponsoring registrar":
+			if info.Registrar == "" {
+				info.Registrar = value
+			}
+		case "creation date", "created", "created on", "registered on", "registration time", "domain registration date":
+			setWHOISTime(&info.Created, value)
+		case "updated date", "last updated", "last modified", "changed", "last-modified", "updated on":
+			setWHOISTime(&info.Updated, value)
+		case "registry expiry date", "registrar registration expiration date", "expiration date", "expiry date", "expires", "expires on", "paid-till", "expiration time":
+			setWHOISTime(&info.Expires, value)
+		case "name server", "nserver", "nameserver", "nameservers":
+			if ns := strings.ToLower(strings.TrimSuffix(strings.Fields(value)[0], ".")); !containsString(info.NameServers, ns) {
+				info.NameServers = append(info.NameServers, ns)
+			}
+		case "registrant country", "registrant country code", "registrant country/economy":
+			if isRedactedValue(value) {
+				info.RegistrantRedacted = true
+			} else if info.RegistrantCountry == "" {
+				info.RegistrantCountry = value
+			}
+		case "domain status", "status", "state":
+			for _, status := range strings.Split(value, ",") {
+				info.Status = appendStatus(info.Status, status)
+			}
+		}
+	}
+
+	return info
+}
+
+func splitWHOISLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "%") || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ">>>") {
+		return "", "", false
+	}
+
+	i := strings.IndexByte(line, ':')
+	if i <= 0 {
+		return "", "", false
+	}
+
+	key = strings.ToLower(strings.TrimSpace(line[:i]))
+	value = strings.TrimSpace(line[i+1:])
+	return key, value, value != ""
+}
+
+func whoisField(raw string, keys ...string) string {
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		key, value, ok := splitWHOISLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		for _, want := range keys {
+			if key == want {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+var whoisTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05.0Z",
+	"2006-01-02T15:04:05.000Z",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+	"02-Jan-2006",
+	"02.01.2006",
+	"January 2 2006",
+	"Mon Jan 2 15:04:05 MST 2006",
+}
+
+func setWHOISTime(dst *time.Time, value string) {
+	if !dst.IsZero() {
+		return
+	}
+	for _, layout := range whoisTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			*dst = t
+			return
+		}
+	}
+	if fields := strings.Fields(value); len(fields) > 1 {
+		setWHOISTime(dst, fields[0])
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
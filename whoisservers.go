@@ -0,0 +1,87 @@
+package domaininfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// defaultWHOISServers maps TLDs to their registry WHOIS servers, taken from
+// the IANA root zone database.
+var defaultWHOISServers = map[string]string{
+	"com":    "whois.verisign-grs.com",
+	"net":    "whois.verisign-grs.com",
+	"org":    "whois.publicinterestregistry.org",
+	"info":   "whois.nic.info",
+	"biz":    "whois.nic.biz",
+	"edu":    "whois.educause.edu",
+	"gov":    "whois.dotgov.gov",
+	"io":     "whois.nic.io",
+	"co":     "whois.nic.co",
+	"me":     "whois.nic.me",
+	"ai":     "whois.nic.ai",
+	"tv":     "whois.nic.tv",
+	"cc":     "ccwhois.verisign-grs.com",
+	"xyz":    "whois.nic.xyz",
+	"online": "whois.nic.online",
+	"app":    "whois.nic.google",
+	"dev":    "whois.nic.google",
+	"uk":     "whois.nic.uk",
+	"de":     "whois.denic.de",
+	"fr":     "whois.nic.fr",
+	"nl":     "whois.domain-registry.nl",
+	"eu":     "whois.eu",
+	"it":     "whois.nic.it",
+	"ch":     "whois.nic.ch",
+	"se":     "whois.iis.se",
+	"ru":     "whois.tcinet.ru",
+	"jp":     "whois.jprs.jp",
+	"cn":     "whois.cnnic.cn",
+	"au":     "whois.auda.org.au",
+	"ca":     "whois.cira.ca",
+	"us":     "whois.nic.us",
+	"in":     "whois.registry.in",
+	"br":     "whois.registro.br",
+}
+
+// whoisServers returns the servers to try for domain, in order: the
+// client's WHOISServers override, the bundled default and any server
+// previously discovered through IANA.
+func (c *Client) whoisServers(domain string) (string, []string) {
+	tld := domain
+	if i := strings.LastIndexByte(domain, '.'); i >= 0 {
+		tld = domain[i+1:]
+	}
+	tld = strings.ToLower(tld)
+
+	var servers []string
+	add := func(server string) {
+		if server != "" && !containsString(servers, server) {
+			servers = append(servers, server)
+		}
+	}
+
+	add(c.WHOISServers[tld])
+	add(defaultWHOISServers[tld])
+	if discovered, ok := c.whoisDiscovered.Load(tld); ok {
+		add(discovered.(string))
+	}
+	return tld, servers
+}
+
+// discoverWHOISServer asks IANA for the WHOIS server of tld and caches the
+// answer on the client.
+func (c *Client) discoverWHOISServer(ctx context.Context, tld string) (string, error) {
+	referral, err := c.queryWHOIS(ctx, ianaWHOIS, tld)
+	if err != nil {
+		return "", fmt.Errorf("IANA WHOIS query failed: %w", err)
+	}
+
+	server := whoisField(referral, "whois", "refer")
+	if server == "" {
+		return "", fmt.Errorf("no WHOIS server known for .%s", tld)
+	}
+
+	c.whoisDiscovered.Store(tld, server)
+	return server, nil
+}